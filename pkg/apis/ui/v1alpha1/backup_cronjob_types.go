@@ -0,0 +1,68 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupCronJob = "BackupCronJob"
+	ResourceBackupCronJob     = "backupcronjob"
+	SubresourceCronJob        = "cronjob"
+)
+
+// BackupCronJobHealthyCondition is shown on a BackupOverview to tell whether
+// the CronJob of its BackupConfiguration agrees with it
+const BackupCronJobHealthyCondition = "CronJobHealthy"
+
+// Reasons of the BackupCronJobHealthyCondition
+const (
+	CronJobReasonHealthy = "CronJobHealthy"
+	CronJobReasonMissing = "CronJobMissing"
+	CronJobReasonDrift   = "CronJobDrift"
+)
+
+// BackupCronJobStatus is the state of the CronJob created by the Stash operator for a BackupConfiguration
+type BackupCronJobStatus struct {
+	Exists bool   `json:"exists"`
+	Name   string `json:"name,omitempty"`
+	// Schedule and Suspended are the ones of the CronJob
+	Schedule           string       `json:"schedule,omitempty"`
+	Suspended          bool         `json:"suspended"`
+	ScheduleMatches    bool         `json:"scheduleMatches"`
+	SuspendMatches     bool         `json:"suspendMatches"`
+	LastScheduleTime   *metav1.Time `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	ActiveJobs         []string     `json:"activeJobs,omitempty"`
+	// Warnings describe how the CronJob disagrees with the BackupConfiguration
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// BackupCronJob is the cronjob subresource of a BackupOverview
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupCronJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status BackupCronJobStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupCronJob{})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupDeletion = "BackupDeletion"
+	ResourceBackupDeletion     = "backupdeletion"
+	SubresourceDeletion        = "deletion"
+)
+
+// +kubebuilder:validation:Enum=Keep;Delete;WipeOut
+type RepositoryDeletionPolicy string
+
+const (
+	// RepositoryDeletionPolicyKeep keeps the Repository
+	RepositoryDeletionPolicyKeep RepositoryDeletionPolicy = "Keep"
+	// RepositoryDeletionPolicyDelete deletes the Repository but keeps the backed up data
+	RepositoryDeletionPolicyDelete RepositoryDeletionPolicy = "Delete"
+	// RepositoryDeletionPolicyWipeOut deletes the Repository along with the backed up data
+	RepositoryDeletionPolicyWipeOut RepositoryDeletionPolicy = "WipeOut"
+)
+
+// BackupDeletionSpec decides what happens to the Repository of a deleted BackupConfiguration.
+// A DELETE on the BackupOverview accepts the same fields as query parameters.
+type BackupDeletionSpec struct {
+	// RepositoryPolicy defaults to Keep
+	RepositoryPolicy RepositoryDeletionPolicy `json:"repositoryPolicy,omitempty"`
+	// Confirm must be the name of the Repository unless it is kept
+	Confirm string `json:"confirm,omitempty"`
+}
+
+// BackupDeletionStatus reports what was deleted
+type BackupDeletionStatus struct {
+	BackupConfiguration string `json:"backupConfiguration,omitempty"`
+	Repository          string `json:"repository,omitempty"`
+	RepositoryDeleted   bool   `json:"repositoryDeleted"`
+	WipedOut            bool   `json:"wipedOut"`
+}
+
+// BackupDeletion is posted to the deletion subresource of a BackupOverview to delete the
+// BackupConfiguration and, optionally, its Repository
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupDeletion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupDeletionSpec   `json:"spec,omitempty"`
+	Status BackupDeletionStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupDeletion{})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupDiagnostics = "BackupDiagnostics"
+	ResourceBackupDiagnostics     = "backupdiagnostics"
+	SubresourceDiagnostics        = "diagnostics"
+)
+
+// BackupDiagnosticsSpec selects the BackupSession to diagnose
+type BackupDiagnosticsSpec struct {
+	// Session is the name of the BackupSession. Defaults to the latest failed
+	// session, or the latest session if none failed.
+	Session string `json:"session,omitempty"`
+	// TailLines is the number of log lines returned per container. Defaults to 50, at most 500.
+	TailLines *int64 `json:"tailLines,omitempty"`
+}
+
+// BackupDiagnosticsStatus describes the Jobs and Pods that ran a BackupSession
+type BackupDiagnosticsStatus struct {
+	Session      string                 `json:"session"`
+	SessionPhase api.BackupSessionPhase `json:"sessionPhase,omitempty"`
+	// Errors are the errors reported on the BackupSession
+	Errors []string         `json:"errors,omitempty"`
+	Jobs   []JobDiagnostics `json:"jobs,omitempty"`
+	Pods   []PodDiagnostics `json:"pods,omitempty"`
+	// Problems summarize what went wrong with the Jobs and Pods
+	Problems []string `json:"problems,omitempty"`
+}
+
+// JobDiagnostics is the state of a backup Job
+type JobDiagnostics struct {
+	Name      string `json:"name"`
+	Active    int32  `json:"active"`
+	Succeeded int32  `json:"succeeded"`
+	Failed    int32  `json:"failed"`
+	// Reason and Message of the Failed condition, e.g. BackoffLimitExceeded
+	FailureReason  string `json:"failureReason,omitempty"`
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
+
+// PodDiagnostics is the state of a Pod of a backup Job
+type PodDiagnostics struct {
+	Name     string        `json:"name"`
+	Job      string        `json:"job,omitempty"`
+	Phase    core.PodPhase `json:"phase,omitempty"`
+	NodeName string        `json:"nodeName,omitempty"`
+	Restarts int32         `json:"restarts"`
+	// SchedulingFailure is the message of the PodScheduled condition if the Pod could not be scheduled
+	SchedulingFailure string                 `json:"schedulingFailure,omitempty"`
+	Containers        []ContainerDiagnostics `json:"containers,omitempty"`
+}
+
+// ContainerDiagnostics is the state of a container along with the tail of its log
+type ContainerDiagnostics struct {
+	Name         string `json:"name"`
+	Init         bool   `json:"init,omitempty"`
+	RestartCount int32  `json:"restartCount"`
+	// State is Waiting, Running or Terminated
+	State    string `json:"state,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// LastTerminationReason and LastExitCode describe the previous run of a restarted container
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+	LastExitCode          *int32 `json:"lastExitCode,omitempty"`
+	LogTail               string `json:"logTail,omitempty"`
+	// LogError tells why the log could not be read
+	LogError string `json:"logError,omitempty"`
+}
+
+// BackupDiagnostics is the diagnostics subresource of a BackupOverview. It
+// is read with get for the default session, or created to select one.
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupDiagnostics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupDiagnosticsSpec   `json:"spec,omitempty"`
+	Status BackupDiagnosticsStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupDiagnostics{})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+const (
+	ResourceKindBackupFailureReport = "BackupFailureReport"
+	ResourceBackupFailureReport     = "backupfailurereport"
+	ResourceBackupFailureReports    = "backupfailurereports"
+)
+
+// +kubebuilder:validation:Enum=Condition;Error
+type FailureSource string
+
+const (
+	FailureSourceCondition FailureSource = "Condition"
+	FailureSourceError     FailureSource = "Error"
+)
+
+// +kubebuilder:validation:Enum=New;Increasing;Decreasing;Unchanged;Resolved
+type FailureTrend string
+
+const (
+	FailureTrendNew        FailureTrend = "New"
+	FailureTrendIncreasing FailureTrend = "Increasing"
+	FailureTrendDecreasing FailureTrend = "Decreasing"
+	FailureTrendUnchanged  FailureTrend = "Unchanged"
+	FailureTrendResolved   FailureTrend = "Resolved"
+)
+
+// BackupFailureReportSpec selects the BackupSessions to analyze
+type BackupFailureReportSpec struct {
+	// Namespace limits the report to a namespace. All namespaces are analyzed if empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Window is the length of the analyzed time window. Defaults to 7 days.
+	Window *metav1.Duration `json:"window,omitempty"`
+	// End of the analyzed time window. Defaults to now.
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// BackupFailureReportStatus is the failure report for the requested window
+type BackupFailureReportStatus struct {
+	Start                  metav1.Time    `json:"start"`
+	End                    metav1.Time    `json:"end"`
+	TotalSessions          int64          `json:"totalSessions"`
+	FailedSessions         int64          `json:"failedSessions"`
+	PreviousFailedSessions int64          `json:"previousFailedSessions"`
+	Groups                 []FailureGroup `json:"groups,omitempty"`
+}
+
+// FailureGroup aggregates the failures that share a condition reason or a similar error text
+type FailureGroup struct {
+	Source FailureSource `json:"source"`
+	// Reason is the condition reason or the normalized error text
+	Reason string `json:"reason"`
+	// Sample is an example error message of the group
+	Sample                 string                       `json:"sample,omitempty"`
+	Count                  int64                        `json:"count"`
+	PreviousCount          int64                        `json:"previousCount"`
+	Trend                  FailureTrend                 `json:"trend"`
+	AffectedConfigurations []kmapi.TypedObjectReference `json:"affectedConfigurations,omitempty"`
+	FirstOccurrence        *metav1.Time                 `json:"firstOccurrence,omitempty"`
+	LastOccurrence         *metav1.Time                 `json:"lastOccurrence,omitempty"`
+}
+
+// BackupFailureReport aggregates why backups failed across BackupSessions
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupFailureReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupFailureReportSpec   `json:"spec,omitempty"`
+	Status BackupFailureReportStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupFailureReport{})
+}
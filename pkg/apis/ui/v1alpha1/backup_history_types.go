@@ -0,0 +1,88 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+const (
+	ResourceKindBackupHistory = "BackupHistory"
+	ResourceBackupHistory     = "backuphistory"
+	ResourceBackupHistories   = "backuphistories"
+)
+
+// BackupHistorySpec selects the point in time to look at
+type BackupHistorySpec struct {
+	// Namespace limits the history to a namespace. All namespaces are returned if empty.
+	Namespace string `json:"namespace,omitempty"`
+	// AsOf is the point in time the overviews are returned for
+	AsOf metav1.Time `json:"asOf"`
+	// Window is the length of the time window ending at AsOf that the sessions
+	// are summarized for. Defaults to 30 days.
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// BackupHistoryStatus holds the overviews and session summaries as recorded at the requested time
+type BackupHistoryStatus struct {
+	// OldestRecord is the time of the oldest record kept by the server
+	OldestRecord *metav1.Time               `json:"oldestRecord,omitempty"`
+	Overviews    []HistoricalBackupOverview `json:"overviews,omitempty"`
+	// Start and End of the summarized time window
+	Start     metav1.Time            `json:"start"`
+	End       metav1.Time            `json:"end"`
+	Summaries []BackupSessionSummary `json:"summaries,omitempty"`
+}
+
+// HistoricalBackupOverview is the overview of a BackupConfiguration as recorded at the requested time
+type HistoricalBackupOverview struct {
+	Namespace string                   `json:"namespace"`
+	Name      string                   `json:"name"`
+	Overview  uiapi.BackupOverviewSpec `json:"overview"`
+	Phase     api.BackupInvokerPhase   `json:"phase,omitempty"`
+}
+
+// BackupSessionSummary counts the outcomes of the BackupSessions of an invoker
+type BackupSessionSummary struct {
+	Invoker       kmapi.TypedObjectReference `json:"invoker"`
+	Total         int64                      `json:"total"`
+	Succeeded     int64                      `json:"succeeded"`
+	Failed        int64                      `json:"failed"`
+	Skipped       int64                      `json:"skipped"`
+	LastSucceeded *metav1.Time               `json:"lastSucceeded,omitempty"`
+}
+
+// BackupHistory returns the overviews and the session summaries recorded by
+// the server at a past point in time. The history outlives the BackupSessions
+// pruned by the backup history limit.
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupHistorySpec   `json:"spec,omitempty"`
+	Status BackupHistoryStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupHistory{})
+}
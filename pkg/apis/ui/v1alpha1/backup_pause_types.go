@@ -0,0 +1,72 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupPause = "BackupPause"
+	ResourceBackupPause     = "backuppause"
+	ResourceBackupPauses    = "backuppauses"
+)
+
+// Annotations recorded on a BackupConfiguration or BackupBatch paused through this server
+const (
+	AnnotationPausedBy    = ui.GroupName + "/paused-by"
+	AnnotationPausedAt    = ui.GroupName + "/paused-at"
+	AnnotationPauseReason = ui.GroupName + "/pause-reason"
+)
+
+// BackupPausedCondition is shown on a BackupOverview that was paused through this server
+const BackupPausedCondition = "Paused"
+
+// BackupPauseSpec selects a backup invoker and its desired state
+type BackupPauseSpec struct {
+	// Invoker is a BackupConfiguration or a BackupBatch in the request namespace
+	Invoker api.BackupInvokerRef `json:"invoker"`
+	Paused  bool                 `json:"paused"`
+	Reason  string               `json:"reason,omitempty"`
+}
+
+// BackupPauseStatus reports the state of the invoker after the request
+type BackupPauseStatus struct {
+	Changed  bool         `json:"changed"`
+	Paused   bool         `json:"paused"`
+	PausedBy string       `json:"pausedBy,omitempty"`
+	PausedAt *metav1.Time `json:"pausedAt,omitempty"`
+	Reason   string       `json:"reason,omitempty"`
+}
+
+// BackupPause pauses or resumes a BackupConfiguration or a BackupBatch
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupPause struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupPauseSpec   `json:"spec,omitempty"`
+	Status BackupPauseStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupPause{})
+}
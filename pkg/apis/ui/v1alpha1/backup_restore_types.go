@@ -0,0 +1,64 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupRestore = "BackupRestore"
+	ResourceBackupRestore     = "backuprestore"
+	SubresourceRestore        = "restore"
+)
+
+// BackupRestoreSpec selects what to restore from the backups of a BackupConfiguration.
+// The latest snapshot of every host is restored if neither Snapshot nor Hosts is set.
+type BackupRestoreSpec struct {
+	// Snapshot restores a single snapshot
+	Snapshot string `json:"snapshot,omitempty"`
+	// Hosts restores the latest snapshot of these source hosts
+	Hosts []string `json:"hosts,omitempty"`
+	// Target is an alternate restore target. Defaults to the backup target.
+	Target  *api.TargetRef   `json:"target,omitempty"`
+	Include []string         `json:"include,omitempty"`
+	Exclude []string         `json:"exclude,omitempty"`
+	TimeOut *metav1.Duration `json:"timeOut,omitempty"`
+}
+
+// BackupRestoreStatus reports the RestoreSession created for the restore
+type BackupRestoreStatus struct {
+	RestoreSession *api.RestoreSession `json:"restoreSession,omitempty"`
+	Warnings       []string            `json:"warnings,omitempty"`
+}
+
+// BackupRestore is posted to the restore subresource of a BackupOverview to start a restore
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupRestoreSpec   `json:"spec,omitempty"`
+	Status BackupRestoreStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupRestore{})
+}
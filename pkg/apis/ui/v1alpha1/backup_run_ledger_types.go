@@ -0,0 +1,104 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupRunLedger = "BackupRunLedger"
+	ResourceBackupRunLedger     = "backuprunledger"
+	SubresourceLedger           = "ledger"
+)
+
+// +kubebuilder:validation:Enum=Matched;Missed;Pending;Unknown
+type BackupRunState string
+
+const (
+	BackupRunMatched BackupRunState = "Matched"
+	BackupRunMissed  BackupRunState = "Missed"
+	// BackupRunPending is a recent run whose BackupSession may still be created
+	BackupRunPending BackupRunState = "Pending"
+	// BackupRunUnknown is a run older than the oldest known BackupSession,
+	// whose BackupSession may have been pruned
+	BackupRunUnknown BackupRunState = "Unknown"
+)
+
+// +kubebuilder:validation:Enum=Paused;CronJobMissing;CronJobSuspended;OperatorDown;Unknown
+type MissedRunCause string
+
+const (
+	MissedRunPaused           MissedRunCause = "Paused"
+	MissedRunCronJobMissing   MissedRunCause = "CronJobMissing"
+	MissedRunCronJobSuspended MissedRunCause = "CronJobSuspended"
+	// MissedRunOperatorDown means the CronJob fired, but no BackupSession was created
+	MissedRunOperatorDown MissedRunCause = "OperatorDown"
+	MissedRunUnknown      MissedRunCause = "Unknown"
+)
+
+// BackupRunLedgerSpec selects the time window of the ledger
+type BackupRunLedgerSpec struct {
+	// Window is the length of the time window. Defaults to 7 days.
+	Window *metav1.Duration `json:"window,omitempty"`
+	// End of the time window. Defaults to now.
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// BackupRunLedgerStatus lists the scheduled runs of the time window
+type BackupRunLedgerStatus struct {
+	Start   metav1.Time `json:"start"`
+	End     metav1.Time `json:"end"`
+	Matched int64       `json:"matched"`
+	Missed  int64       `json:"missed"`
+	Unknown int64       `json:"unknown"`
+	Runs    []BackupRun `json:"runs,omitempty"`
+	// UnscheduledSessions are the BackupSessions of the window that did not
+	// match a scheduled run, e.g. the ones triggered manually
+	UnscheduledSessions []string `json:"unscheduledSessions,omitempty"`
+}
+
+// BackupRun is a fire time of the schedule along with the BackupSession created for it
+type BackupRun struct {
+	ScheduledTime metav1.Time            `json:"scheduledTime"`
+	State         BackupRunState         `json:"state"`
+	Session       string                 `json:"session,omitempty"`
+	SessionPhase  api.BackupSessionPhase `json:"sessionPhase,omitempty"`
+	Created       *metav1.Time           `json:"created,omitempty"`
+	Cause         MissedRunCause         `json:"cause,omitempty"`
+	Message       string                 `json:"message,omitempty"`
+}
+
+// BackupRunLedger is the ledger subresource of a BackupOverview. It matches
+// the fire times of the schedule to the BackupSessions and tells the likely
+// cause of the missed runs. It is read with get, or created to select the
+// time window.
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupRunLedger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupRunLedgerSpec   `json:"spec,omitempty"`
+	Status BackupRunLedgerStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupRunLedger{})
+}
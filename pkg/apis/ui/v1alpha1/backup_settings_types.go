@@ -0,0 +1,55 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupSettings = "BackupSettings"
+	ResourceBackupSettings     = "backupsettings"
+	SubresourceSettings        = "settings"
+)
+
+// BackupSettingsSpec is the editable part of a BackupConfiguration
+type BackupSettingsSpec struct {
+	Schedule           string                   `json:"schedule"`
+	Paused             bool                     `json:"paused"`
+	RetentionPolicy    v1alpha1.RetentionPolicy `json:"retentionPolicy"`
+	TimeOut            *metav1.Duration         `json:"timeOut,omitempty"`
+	RetryConfig        *api.RetryConfig         `json:"retryConfig,omitempty"`
+	BackupHistoryLimit *int32                   `json:"backupHistoryLimit,omitempty"`
+}
+
+// BackupSettings is the settings subresource of a BackupOverview. Its
+// resourceVersion is the one of the BackupConfiguration.
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupSettings struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackupSettingsSpec `json:"spec,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupSettings{})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupSetup = "BackupSetup"
+	ResourceBackupSetup     = "backupsetup"
+	ResourceBackupSetups    = "backupsetups"
+)
+
+// +kubebuilder:validation:Enum=s3;gcs;azure;swift;b2
+type BackendType string
+
+const (
+	BackendTypeS3    BackendType = "s3"
+	BackendTypeGCS   BackendType = "gcs"
+	BackendTypeAzure BackendType = "azure"
+	BackendTypeSwift BackendType = "swift"
+	BackendTypeB2    BackendType = "b2"
+)
+
+// +kubebuilder:validation:Enum=Minimal;Daily;Weekly;Monthly
+type RetentionPreset string
+
+const (
+	// RetentionPresetMinimal keeps the last 5 snapshots
+	RetentionPresetMinimal RetentionPreset = "Minimal"
+	// RetentionPresetDaily keeps a snapshot per day for a week
+	RetentionPresetDaily RetentionPreset = "Daily"
+	// RetentionPresetWeekly keeps daily snapshots for a week and weekly ones for a month
+	RetentionPresetWeekly RetentionPreset = "Weekly"
+	// RetentionPresetMonthly keeps daily, weekly and monthly snapshots for a year
+	RetentionPresetMonthly RetentionPreset = "Monthly"
+)
+
+// BackupSetupSpec is a simplified form of a Repository and a BackupConfiguration
+type BackupSetupSpec struct {
+	Target api.TargetRef `json:"target"`
+	// Paths and VolumeMounts are required to back up workloads
+	Paths        []string           `json:"paths,omitempty"`
+	VolumeMounts []core.VolumeMount `json:"volumeMounts,omitempty"`
+	Backend      BackupSetupBackend `json:"backend"`
+	Schedule     string             `json:"schedule"`
+	// RetentionPreset defaults to Daily
+	RetentionPreset RetentionPreset `json:"retentionPreset,omitempty"`
+	// Task is the backup Task. It is resolved by Stash if empty.
+	Task string `json:"task,omitempty"`
+}
+
+// BackupSetupBackend describes where the backed up data is stored
+type BackupSetupBackend struct {
+	Type BackendType `json:"type"`
+	// Bucket is the bucket or, for azure and swift, the container
+	Bucket string `json:"bucket"`
+	// Prefix defaults to <namespace>/<name>
+	Prefix string `json:"prefix,omitempty"`
+	// Endpoint and Region are used by s3. Endpoint defaults to s3.amazonaws.com.
+	Endpoint          string `json:"endpoint,omitempty"`
+	Region            string `json:"region,omitempty"`
+	StorageSecretName string `json:"storageSecretName"`
+}
+
+// BackupSetupStatus reports the objects created for the setup
+type BackupSetupStatus struct {
+	Repository          string `json:"repository,omitempty"`
+	BackupConfiguration string `json:"backupConfiguration,omitempty"`
+}
+
+// BackupSetup creates a Repository and a BackupConfiguration together
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupSetup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupSetupSpec   `json:"spec,omitempty"`
+	Status BackupSetupStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupSetup{})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupSidecar = "BackupSidecar"
+	ResourceBackupSidecar     = "backupsidecar"
+	SubresourceSidecar        = "sidecar"
+)
+
+// BackupSidecarStatus tells which Pods of the target workload of a
+// sidecar-model BackupConfiguration run the stash sidecar
+type BackupSidecarStatus struct {
+	// Applicable is false if the target is not a Deployment or StatefulSet
+	Applicable bool   `json:"applicable"`
+	Target     string `json:"target,omitempty"`
+	// Injected and InjectionMessage are taken from the StashSidecarInjected
+	// condition of the BackupConfiguration
+	Injected         bool   `json:"injected"`
+	InjectionMessage string `json:"injectionMessage,omitempty"`
+	// SpecHash is the hash of the BackupConfiguration applied to the pod template of the target
+	SpecHash string `json:"specHash,omitempty"`
+
+	Pods int32 `json:"pods"`
+	// UpToDate Pods run the sidecar of the current SpecHash
+	UpToDate int32 `json:"upToDate"`
+	// Stale Pods run a sidecar of another SpecHash and need a restart
+	Stale int32 `json:"stale"`
+	// Missing Pods don't run the sidecar
+	Missing     int32    `json:"missing"`
+	StalePods   []string `json:"stalePods,omitempty"`
+	MissingPods []string `json:"missingPods,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// BackupSidecar is the sidecar subresource of a BackupOverview
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupSidecar struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status BackupSidecarStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupSidecar{})
+}
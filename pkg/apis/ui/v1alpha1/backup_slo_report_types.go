@@ -0,0 +1,82 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupSLOReport = "BackupSLOReport"
+	ResourceBackupSLOReport     = "backupsloreport"
+	ResourceBackupSLOReports    = "backupsloreports"
+)
+
+// BackupSLOReportSpec selects the BackupConfigurations and the time window of the report
+type BackupSLOReportSpec struct {
+	// Namespace limits the report to a namespace. All namespaces are reported if empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Window is the length of the reported time window. Defaults to 30 days, at most 366 days.
+	Window *metav1.Duration `json:"window,omitempty"`
+	// End of the reported time window. Defaults to now.
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// BackupSLOReportStatus holds the service level of the backups per configuration and per namespace
+type BackupSLOReportStatus struct {
+	Start          metav1.Time `json:"start"`
+	End            metav1.Time `json:"end"`
+	Configurations []BackupSLO `json:"configurations,omitempty"`
+	Namespaces     []BackupSLO `json:"namespaces,omitempty"`
+}
+
+// BackupSLO is the service level of a BackupConfiguration, or of all
+// BackupConfigurations of a namespace if the name is empty
+type BackupSLO struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name,omitempty"`
+	// ExpectedRuns is the number of runs scheduled by the cron schedule
+	ExpectedRuns int64 `json:"expectedRuns"`
+	Created      int64 `json:"created"`
+	Succeeded    int64 `json:"succeeded"`
+	Failed       int64 `json:"failed"`
+	Skipped      int64 `json:"skipped"`
+	// SuccessRate is the percentage of the expected runs that succeeded. Sessions
+	// triggered manually count as expected runs if they exceed the scheduled ones.
+	SuccessRate *float64 `json:"successRate,omitempty"`
+	// MeanTimeBetweenFailures is the reported time divided by the number of failures
+	MeanTimeBetweenFailures *metav1.Duration `json:"meanTimeBetweenFailures,omitempty"`
+	// LongestGap is the longest time without a successful backup
+	LongestGap      *metav1.Duration `json:"longestGap,omitempty"`
+	LongestGapStart *metav1.Time     `json:"longestGapStart,omitempty"`
+}
+
+// BackupSLOReport computes the backup success rate over a time window from
+// the BackupSessions, their Events and the history recorded by the server
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupSLOReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupSLOReportSpec   `json:"spec,omitempty"`
+	Status BackupSLOReportStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupSLOReport{})
+}
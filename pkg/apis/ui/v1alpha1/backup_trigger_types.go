@@ -0,0 +1,53 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindBackupTrigger = "BackupTrigger"
+	ResourceBackupTrigger     = "backuptrigger"
+	SubresourceTrigger        = "trigger"
+)
+
+// BackupLastSessionCondition is shown on a BackupOverview to tell the phase of
+// the latest BackupSession of its BackupConfiguration, which is its reason
+const BackupLastSessionCondition = "LastBackupSession"
+
+// BackupTriggerStatus reports the BackupSession created for an on-demand backup
+type BackupTriggerStatus struct {
+	BackupSession string               `json:"backupSession,omitempty"`
+	Invoker       api.BackupInvokerRef `json:"invoker,omitempty"`
+}
+
+// BackupTrigger is posted to the trigger subresource of a BackupOverview to take a backup now
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupTrigger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status BackupTriggerStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupTrigger{})
+}
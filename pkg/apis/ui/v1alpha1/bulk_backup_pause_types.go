@@ -0,0 +1,82 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+const (
+	ResourceKindBulkBackupPause = "BulkBackupPause"
+	ResourceBulkBackupPause     = "bulkbackuppause"
+	ResourceBulkBackupPauses    = "bulkbackuppauses"
+)
+
+// +kubebuilder:validation:Enum=Changed;Unchanged;Forbidden;Error
+type BulkBackupPauseResult string
+
+const (
+	BulkBackupPauseChanged   BulkBackupPauseResult = "Changed"
+	BulkBackupPauseUnchanged BulkBackupPauseResult = "Unchanged"
+	BulkBackupPauseForbidden BulkBackupPauseResult = "Forbidden"
+	BulkBackupPauseError     BulkBackupPauseResult = "Error"
+)
+
+// BulkBackupPauseSpec selects the backup invokers to pause or resume
+type BulkBackupPauseSpec struct {
+	// Namespace limits the selection to a namespace. All namespaces are selected if empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Kinds limits the selection to BackupConfiguration or BackupBatch. Both are selected if empty.
+	Kinds []string `json:"kinds,omitempty"`
+	// Selector selects the invokers by label. It is required unless All is set.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// All selects every invoker of the namespace, or of the cluster if Namespace is empty
+	All    bool   `json:"all,omitempty"`
+	Paused bool   `json:"paused"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// BulkBackupPauseStatus reports the outcome for every selected invoker
+type BulkBackupPauseStatus struct {
+	Items []BulkBackupPauseItem `json:"items,omitempty"`
+	// Forbidden counts the selected invokers that the user may not get. They
+	// are left out of Items so that their names are not disclosed.
+	Forbidden int32 `json:"forbidden,omitempty"`
+}
+
+// BulkBackupPauseItem is the outcome for a single invoker
+type BulkBackupPauseItem struct {
+	Invoker kmapi.TypedObjectReference `json:"invoker"`
+	Result  BulkBackupPauseResult      `json:"result"`
+	Message string                     `json:"message,omitempty"`
+}
+
+// BulkBackupPause pauses or resumes the backup invokers matching a label selector
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BulkBackupPause struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BulkBackupPauseSpec   `json:"spec,omitempty"`
+	Status BulkBackupPauseStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BulkBackupPause{})
+}
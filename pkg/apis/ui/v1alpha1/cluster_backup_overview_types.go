@@ -0,0 +1,66 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindClusterBackupOverview = "ClusterBackupOverview"
+	ResourceClusterBackupOverview     = "clusterbackupoverview"
+	ResourceClusterBackupOverviews    = "clusterbackupoverviews"
+)
+
+// LabelCluster holds the name of the member cluster an aggregated object was read from
+const LabelCluster = "ui.stash.appscode.com/cluster"
+
+// ClusterBackupOverviewSpec is the overview of a BackupConfiguration in one of the member clusters
+type ClusterBackupOverviewSpec struct {
+	Cluster   string                   `json:"cluster"`
+	Namespace string                   `json:"namespace"`
+	Name      string                   `json:"name"`
+	Overview  uiapi.BackupOverviewSpec `json:"overview"`
+}
+
+// ClusterBackupOverview is a BackupOverview merged from the local and the member clusters.
+// It is named <cluster>.<namespace>.<name>.
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterBackupOverview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterBackupOverviewSpec     `json:"spec,omitempty"`
+	Status api.BackupConfigurationStatus `json:"status,omitempty"`
+}
+
+// ClusterBackupOverviewList contains a list of ClusterBackupOverview
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterBackupOverviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBackupOverview `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterBackupOverview{}, &ClusterBackupOverviewList{})
+}
@@ -0,0 +1,76 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindMemberCluster = "MemberCluster"
+	ResourceMemberCluster     = "membercluster"
+	ResourceMemberClusters    = "memberclusters"
+)
+
+// +kubebuilder:validation:Enum=Unknown;Healthy;Unreachable
+type MemberClusterPhase string
+
+const (
+	MemberClusterUnknown     MemberClusterPhase = "Unknown"
+	MemberClusterHealthy     MemberClusterPhase = "Healthy"
+	MemberClusterUnreachable MemberClusterPhase = "Unreachable"
+)
+
+// MemberClusterSpec describes how a cluster is reached
+type MemberClusterSpec struct {
+	// Local is true for the cluster the server runs in
+	Local bool `json:"local,omitempty"`
+	// Context is the kubeconfig context used to reach a member cluster
+	Context string `json:"context,omitempty"`
+}
+
+// MemberClusterStatus is the outcome of the latest health probe
+type MemberClusterStatus struct {
+	Phase                MemberClusterPhase `json:"phase,omitempty"`
+	Message              string             `json:"message,omitempty"`
+	LastProbeTime        *metav1.Time       `json:"lastProbeTime,omitempty"`
+	BackupConfigurations int                `json:"backupConfigurations,omitempty"`
+}
+
+// MemberCluster is a cluster whose backups are aggregated into the ClusterBackupOverviews
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MemberCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MemberClusterSpec   `json:"spec,omitempty"`
+	Status MemberClusterStatus `json:"status,omitempty"`
+}
+
+// MemberClusterList contains a list of MemberCluster
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MemberClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MemberCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MemberCluster{}, &MemberClusterList{})
+}
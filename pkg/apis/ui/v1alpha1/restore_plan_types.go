@@ -0,0 +1,99 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+const (
+	ResourceKindRestorePlan = "RestorePlan"
+	ResourceRestorePlan     = "restoreplan"
+	ResourceRestorePlans    = "restoreplans"
+)
+
+// RestorePlanSpec describes a restore that the caller intends to run
+type RestorePlanSpec struct {
+	Repository kmapi.ObjectReference `json:"repository"`
+	Target     api.TargetRef         `json:"target"`
+	Task       api.TaskRef           `json:"task,omitempty"`
+	Rules      []api.Rule            `json:"rules,omitempty"`
+	Hooks      *api.RestoreHooks     `json:"hooks,omitempty"`
+	TimeOut    *metav1.Duration      `json:"timeOut,omitempty"`
+}
+
+// RestorePlanStatus is the resolved plan for a RestorePlanSpec
+type RestorePlanStatus struct {
+	Task     *RestorePlanTask  `json:"task,omitempty"`
+	Hosts    []RestorePlanHost `json:"hosts,omitempty"`
+	DataSize string            `json:"dataSize,omitempty"`
+	Hooks    []RestorePlanHook `json:"hooks,omitempty"`
+	TimeOut  *metav1.Duration  `json:"timeOut,omitempty"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// RestorePlanTask is the Task that the restore will run
+type RestorePlanTask struct {
+	Name   string      `json:"name"`
+	Params []api.Param `json:"params,omitempty"`
+	Steps  []string    `json:"steps,omitempty"`
+}
+
+// RestorePlanHost lists the snapshots that will be restored into a target host
+type RestorePlanHost struct {
+	TargetHost string                `json:"targetHost"`
+	SourceHost string                `json:"sourceHost"`
+	Snapshots  []RestorePlanSnapshot `json:"snapshots,omitempty"`
+	Include    []string              `json:"include,omitempty"`
+	Exclude    []string              `json:"exclude,omitempty"`
+	DataSize   string                `json:"dataSize,omitempty"`
+}
+
+// RestorePlanSnapshot is a snapshot picked from the BackupSession history
+type RestorePlanSnapshot struct {
+	Name          string       `json:"name"`
+	Path          string       `json:"path,omitempty"`
+	TotalSize     string       `json:"totalSize,omitempty"`
+	BackupSession string       `json:"backupSession,omitempty"`
+	CreatedAt     *metav1.Time `json:"createdAt,omitempty"`
+}
+
+// RestorePlanHook is a hook that will be executed during the restore
+type RestorePlanHook struct {
+	Name            string                  `json:"name"`
+	Action          string                  `json:"action"`
+	ContainerName   string                  `json:"containerName,omitempty"`
+	ExecutionPolicy api.HookExecutionPolicy `json:"executionPolicy,omitempty"`
+}
+
+// RestorePlan previews what a RestoreSession with the given spec will do
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RestorePlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RestorePlanSpec   `json:"spec,omitempty"`
+	Status RestorePlanStatus `json:"status,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RestorePlan{})
+}
@@ -19,22 +19,41 @@ package apiserver
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	stashinstall "stash.appscode.dev/apimachinery/apis/stash/install"
 	"stash.appscode.dev/apimachinery/apis/ui"
 	uiinstall "stash.appscode.dev/apimachinery/apis/ui/install"
 	uiv1alpha1 "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/clusters"
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+	"stash.appscode.dev/ui-server/pkg/instrumentation"
+	"stash.appscode.dev/ui-server/pkg/metrics"
 	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+	historyreg "stash.appscode.dev/ui-server/pkg/registry/ui/history"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/ledger"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/restores"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/slo"
+	"stash.appscode.dev/ui-server/pkg/shared"
 
+	batchv1 "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/klogr"
 	"kmodules.xyz/authorizer/rbac"
 	cu "kmodules.xyz/client-go/client"
@@ -42,6 +61,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
@@ -55,6 +75,7 @@ var (
 
 func init() {
 	uiinstall.Install(Scheme)
+	utilruntime.Must(uisrv.AddToScheme(Scheme))
 	stashinstall.Install(Scheme)
 	appcataloginstall.Install(Scheme)
 
@@ -77,7 +98,44 @@ func init() {
 
 // ExtraConfig holds custom apiserver config
 type ExtraConfig struct {
-	ClientConfig *restclient.Config
+	ClientConfig       *restclient.Config
+	MetricsBindAddress string
+	// ClusterName is the name of the local cluster in the aggregated views
+	ClusterName string
+	// MemberClusters are given as NAME=KUBECONFIG[:CONTEXT]
+	MemberClusters []string
+	// Authorizer replaces the RBAC authorizer of the storages if set
+	Authorizer authorizer.Authorizer
+	// UserClient replaces the impersonating client used for the writes made
+	// on behalf of the requester if set
+	UserClient shared.UserClient
+	Evaluator  EvaluatorConfig
+	History    HistoryConfig
+}
+
+// EvaluatorConfig configures the background evaluation of the backup state
+type EvaluatorConfig struct {
+	// Interval between two evaluations. The evaluator is disabled if zero.
+	Interval         time.Duration
+	PausedAlertAfter time.Duration
+	// NotificationConfig is the path to the webhook config. No webhooks are notified if empty.
+	NotificationConfig      string
+	NotificationDedupWindow time.Duration
+	// EventConditions are the conditions recorded as Events on the BackupConfigurations
+	EventConditions map[evaluator.Condition]bool
+	EventQPS        float32
+	EventBurst      int
+	// LeaderElection makes the replicas of the server elect the one that
+	// evaluates. Every replica evaluates if false.
+	LeaderElection bool
+}
+
+// HistoryConfig configures the persistent history of the backup state
+type HistoryConfig struct {
+	// Dir is where the history is stored, usually a persistent volume. The history is disabled if empty.
+	Dir       string
+	Interval  time.Duration
+	Retention time.Duration
 }
 
 // Config defines the config for the apiserver
@@ -113,6 +171,12 @@ func (cfg *Config) Complete() CompletedConfig {
 
 // New returns a new instance of UIServer from the given config.
 func (c completedConfig) New(ctx context.Context) (*UIServer, error) {
+	gate := newResourceGate()
+	if build := c.GenericConfig.BuildHandlerChainFunc; build != nil {
+		c.GenericConfig.BuildHandlerChainFunc = func(apiHandler http.Handler, conf *genericapiserver.Config) http.Handler {
+			return build(gate.withGate(apiHandler), conf)
+		}
+	}
 	genericServer, err := c.GenericConfig.New("stash-ui-server", genericapiserver.NewEmptyDelegate())
 	if err != nil {
 		return nil, err
@@ -123,15 +187,24 @@ func (c completedConfig) New(ctx context.Context) (*UIServer, error) {
 
 	mgr, err := manager.New(c.ExtraConfig.ClientConfig, manager.Options{
 		Scheme:                 Scheme,
-		Metrics:                metricsserver.Options{BindAddress: ""},
+		Metrics:                metricsserver.Options{BindAddress: c.ExtraConfig.MetricsBindAddress},
 		HealthProbeBindAddress: "",
-		LeaderElection:         false,
+		LeaderElection:         c.ExtraConfig.Evaluator.LeaderElection && c.ExtraConfig.Evaluator.enabled(),
 		LeaderElectionID:       "5b87adeb.ui.stash.appscode.com",
-		NewClient:              cu.NewClient,
+		NewClient: func(config *restclient.Config, options client.Options) (client.Client, error) {
+			c, err := cu.NewClient(config, options)
+			if err != nil {
+				return nil, err
+			}
+			return instrumentation.NewClient(c, options.Cache), nil
+		},
 		Client: client.Options{
 			Cache: &client.CacheOptions{
+				// the CronJobs are listed per namespace of a BackupConfiguration,
+				// which does not justify watching every CronJob of the cluster
 				DisableFor: []client.Object{
 					&core.Pod{},
+					&batchv1.CronJob{},
 				},
 			},
 		},
@@ -141,7 +214,61 @@ func (c completedConfig) New(ctx context.Context) (*UIServer, error) {
 	}
 	ctrlClient := mgr.GetClient()
 
-	rbacAuthorizer := rbac.NewForManagerOrDie(ctx, mgr)
+	authz := c.ExtraConfig.Authorizer
+	if authz == nil {
+		authz = rbac.NewForManagerOrDie(ctx, mgr)
+	}
+	rbacAuthorizer := instrumentation.NewAuthorizer(authz)
+
+	userClient := c.ExtraConfig.UserClient
+	if userClient == nil {
+		userClient, err = shared.ImpersonatingClient(c.ExtraConfig.ClientConfig, client.Options{Scheme: Scheme, Mapper: mgr.GetRESTMapper()})
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the impersonating client, reason: %v", err)
+		}
+	}
+
+	if err := ctrlmetrics.Registry.Register(metrics.NewBackupCollector(ctrlClient)); err != nil {
+		return nil, fmt.Errorf("unable to register backup metrics, reason: %v", err)
+	}
+
+	if err := clusters.ValidateName(c.ExtraConfig.ClusterName); err != nil {
+		return nil, fmt.Errorf("invalid cluster name: %v", err)
+	}
+	members := make([]*clusters.Member, 0, len(c.ExtraConfig.MemberClusters))
+	for _, spec := range c.ExtraConfig.MemberClusters {
+		name, kubeconfig, kubeContext, err := clusters.ParseMember(spec)
+		if err != nil {
+			return nil, err
+		}
+		m, err := clusters.NewMember(name, kubeconfig, kubeContext, Scheme)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	clusterSet := clusters.NewSet(clusters.NewLocal(c.ExtraConfig.ClusterName, ctrlClient), members...)
+	if err := mgr.Add(clusterSet); err != nil {
+		return nil, err
+	}
+
+	if err := c.ExtraConfig.Evaluator.setup(mgr, ctrlClient, c.ExtraConfig.ClusterName); err != nil {
+		return nil, err
+	}
+
+	historyStore, err := c.ExtraConfig.History.setup(mgr, ctrlClient)
+	if err != nil {
+		return nil, err
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(c.ExtraConfig.ClientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create discovery client, reason: %v", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(c.ExtraConfig.ClientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create kubernetes client, reason: %v", err)
+	}
 
 	s := &UIServer{
 		GenericAPIServer: genericServer,
@@ -151,9 +278,50 @@ func (c completedConfig) New(ctx context.Context) (*UIServer, error) {
 	{
 		apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(ui.GroupName, Scheme, metav1.ParameterCodec, Codecs)
 
-		v1alpha1storage := map[string]rest.Storage{}
+		missing, err := missingResources(dc, backupConfigurations, backupBatches, backupSessions, restoreSessions, repositories)
+		if err != nil {
+			return nil, fmt.Errorf("unable to discover Stash resources, reason: %v", err)
+		}
+		missingSet := sets.New(missing...)
 
-		v1alpha1storage[uiv1alpha1.ResourceBackupOverviews] = backups.NewBackupOverviewStorage(ctrlClient, rbacAuthorizer)
+		v1alpha1storage := map[string]rest.Storage{}
+		for _, r := range []struct {
+			resource string
+			storage  rest.Storage
+			requires []schema.GroupVersionResource
+		}{
+			{uiv1alpha1.ResourceBackupOverviews, backups.NewBackupOverviewStorage(ctrlClient, userClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, repositories}},
+			{uiv1alpha1.ResourceBackupOverviews + "/" + uisrv.SubresourceTrigger, backups.NewBackupTriggerStorage(ctrlClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, repositories, backupSessions}},
+			{uiv1alpha1.ResourceBackupOverviews + "/" + uisrv.SubresourceRestore, backups.NewBackupRestoreStorage(ctrlClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, repositories, backupSessions, restoreSessions}},
+			{uiv1alpha1.ResourceBackupOverviews + "/" + uisrv.SubresourceSettings, backups.NewBackupSettingsStorage(ctrlClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, repositories}},
+			{uiv1alpha1.ResourceBackupOverviews + "/" + uisrv.SubresourceDeletion, backups.NewBackupDeletionStorage(ctrlClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, repositories}},
+			{uiv1alpha1.ResourceBackupOverviews + "/" + uisrv.SubresourceCronJob, backups.NewBackupCronJobStorage(ctrlClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations}},
+			{uiv1alpha1.ResourceBackupOverviews + "/" + uisrv.SubresourceLedger, ledger.NewBackupRunLedgerStorage(ctrlClient, mgr.GetAPIReader(), historyStore, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, backupSessions}},
+			{uiv1alpha1.ResourceBackupOverviews + "/" + uisrv.SubresourceDiagnostics, backups.NewBackupDiagnosticsStorage(ctrlClient, mgr.GetAPIReader(), kubeClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, backupSessions}},
+			{uiv1alpha1.ResourceBackupOverviews + "/" + uisrv.SubresourceSidecar, backups.NewBackupSidecarStorage(ctrlClient, mgr.GetAPIReader(), rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations}},
+			{uisrv.ResourceRestorePlans, restores.NewRestorePlanStorage(ctrlClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, repositories, backupSessions}},
+			{uisrv.ResourceBackupFailureReports, backups.NewBackupFailureReportStorage(ctrlClient, rbacAuthorizer), []schema.GroupVersionResource{backupSessions}},
+			{uisrv.ResourceBackupSLOReports, slo.NewBackupSLOReportStorage(ctrlClient, mgr.GetAPIReader(), historyStore, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, backupSessions}},
+			{uisrv.ResourceBackupPauses, backups.NewBackupPauseStorage(ctrlClient, userClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations}},
+			{uisrv.ResourceBulkBackupPauses, backups.NewBulkBackupPauseStorage(ctrlClient, userClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, backupBatches}},
+			{uisrv.ResourceBackupSetups, backups.NewBackupSetupStorage(ctrlClient, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, repositories}},
+			{uisrv.ResourceClusterBackupOverviews, backups.NewClusterBackupOverviewStorage(clusterSet, rbacAuthorizer), []schema.GroupVersionResource{backupConfigurations, repositories}},
+			{uisrv.ResourceMemberClusters, backups.NewMemberClusterStorage(clusterSet, rbacAuthorizer), nil},
+		} {
+			if absent := missingSet.Intersection(sets.New(r.requires...)); absent.Len() > 0 {
+				klog.Warningf("%s is unavailable until the Stash resources %s are served", r.resource, joinResources(absent.UnsortedList()))
+				gate.close(r.resource, r.requires)
+			}
+			v1alpha1storage[r.resource] = r.storage
+		}
+		if historyStore != nil {
+			v1alpha1storage[uisrv.ResourceBackupHistories] = historyreg.NewBackupHistoryStorage(historyStore, rbacAuthorizer)
+		}
+		if len(gate.closed()) > 0 {
+			if err := mgr.Add(newCRDWatcher(dc, gate)); err != nil {
+				return nil, err
+			}
+		}
 
 		apiGroupInfo.VersionedResourcesStorageMap["v1alpha1"] = v1alpha1storage
 
@@ -162,5 +330,12 @@ func (c completedConfig) New(ctx context.Context) (*UIServer, error) {
 		}
 	}
 
+	if err := s.GenericAPIServer.AddReadyzChecks(newStashResourcesCheck(dc)); err != nil {
+		return nil, err
+	}
+	if err := s.GenericAPIServer.AddBootSequenceHealthChecks(newCacheSyncCheck(mgr.GetCache())); err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
@@ -0,0 +1,238 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	crdPollInterval  = 30 * time.Second
+	cacheSyncTimeout = time.Second
+)
+
+var (
+	backupConfigurations = stashv1beta1.SchemeGroupVersion.WithResource(stashv1beta1.ResourcePluralBackupConfiguration)
+	backupBatches        = stashv1beta1.SchemeGroupVersion.WithResource(stashv1beta1.ResourcePluralBackupBatch)
+	backupSessions       = stashv1beta1.SchemeGroupVersion.WithResource(stashv1beta1.ResourcePluralBackupSession)
+	restoreSessions      = stashv1beta1.SchemeGroupVersion.WithResource(stashv1beta1.ResourcePluralRestoreSession)
+	repositories         = stashv1alpha1.SchemeGroupVersion.WithResource(stashv1alpha1.ResourcePluralRepository)
+
+	// requiredResources must be served for the server to be ready
+	requiredResources = []schema.GroupVersionResource{backupConfigurations, repositories, backupSessions}
+)
+
+// missingResources returns the resources that are not served by the cluster
+func missingResources(dc discovery.DiscoveryInterface, gvrs ...schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	served := map[schema.GroupVersion]sets.Set[string]{}
+	var missing []schema.GroupVersionResource
+	for _, gvr := range gvrs {
+		gv := gvr.GroupVersion()
+		resources, found := served[gv]
+		if !found {
+			resources = sets.New[string]()
+			list, err := dc.ServerResourcesForGroupVersion(gv.String())
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			if list != nil {
+				for _, r := range list.APIResources {
+					resources.Insert(r.Name)
+				}
+			}
+			served[gv] = resources
+		}
+		if !resources.Has(gvr.Resource) {
+			missing = append(missing, gvr)
+		}
+	}
+	return missing, nil
+}
+
+// stashResourcesCheck fails while any of the required Stash resources is not
+// served. The result is reused for a while, so that frequent probes do not
+// each hit discovery.
+type stashResourcesCheck struct {
+	dc  discovery.DiscoveryInterface
+	ttl time.Duration
+
+	mu      sync.Mutex
+	checked time.Time
+	err     error
+}
+
+func newStashResourcesCheck(dc discovery.DiscoveryInterface) *stashResourcesCheck {
+	return &stashResourcesCheck{dc: dc, ttl: crdPollInterval}
+}
+
+var _ healthz.HealthChecker = &stashResourcesCheck{}
+
+func (c *stashResourcesCheck) Name() string {
+	return "stash-resources"
+}
+
+func (c *stashResourcesCheck) Check(_ *http.Request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.checked.IsZero() && time.Since(c.checked) < c.ttl {
+		return c.err
+	}
+
+	missing, err := missingResources(c.dc, requiredResources...)
+	if err == nil && len(missing) > 0 {
+		err = fmt.Errorf("missing Stash resources %s", joinResources(missing))
+	}
+	c.checked = time.Now()
+	c.err = err
+	return err
+}
+
+// newCacheSyncCheck fails until the informers of the manager have synced
+func newCacheSyncCheck(c cache.Cache) healthz.HealthChecker {
+	return healthz.NamedCheck("manager-cache-sync", func(r *http.Request) error {
+		ctx, cancel := context.WithTimeout(r.Context(), cacheSyncTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("manager cache has not synced")
+		}
+		return nil
+	})
+}
+
+// resourceGate answers the requests to the ui resources whose Stash resources
+// are not served yet with 503. The generic API server does not support adding
+// resources to an installed group, so these resources are installed anyway and
+// opened once the CRD watcher finds their Stash resources.
+type resourceGate struct {
+	mu      sync.RWMutex
+	pending map[string][]schema.GroupVersionResource
+}
+
+func newResourceGate() *resourceGate {
+	return &resourceGate{pending: map[string][]schema.GroupVersionResource{}}
+}
+
+// close holds back the requests to resource until open is called
+func (g *resourceGate) close(resource string, requires []schema.GroupVersionResource) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pending[resource] = requires
+}
+
+func (g *resourceGate) open(resource string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.pending, resource)
+}
+
+// closed returns the closed resources along with the Stash resources they require
+func (g *resourceGate) closed() map[string][]schema.GroupVersionResource {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	out := make(map[string][]schema.GroupVersionResource, len(g.pending))
+	for name, gvrs := range g.pending {
+		out[name] = gvrs
+	}
+	return out
+}
+
+// withGate rejects the requests to the closed ui resources
+func (g *resourceGate) withGate(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, ok := apirequest.RequestInfoFrom(req.Context())
+		if ok && info.IsResourceRequest && info.APIGroup == ui.GroupName {
+			resource := info.Resource
+			if info.Subresource != "" {
+				resource += "/" + info.Subresource
+			}
+			g.mu.RLock()
+			requires, closed := g.pending[resource]
+			g.mu.RUnlock()
+			if closed {
+				err := apierrors.NewServiceUnavailable(fmt.Sprintf("%s requires the Stash resources %s, which are not served yet", resource, joinResources(requires)))
+				responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{Group: info.APIGroup, Version: info.APIVersion}, w, req)
+				return
+			}
+		}
+		h.ServeHTTP(w, req)
+	})
+}
+
+// openServed opens the closed resources whose Stash resources are all served
+// and reports whether none is left closed.
+func (g *resourceGate) openServed(dc discovery.DiscoveryInterface) (bool, error) {
+	closed := g.closed()
+	for name, gvrs := range closed {
+		missing, err := missingResources(dc, gvrs...)
+		if err != nil {
+			return false, err
+		}
+		if len(missing) == 0 {
+			klog.Infof("serving %s as the Stash resources it requires are now served", name)
+			g.open(name)
+			delete(closed, name)
+		}
+	}
+	return len(closed) == 0, nil
+}
+
+// newCRDWatcher opens the closed resources of gate once all the Stash
+// resources they require are served.
+func newCRDWatcher(dc discovery.DiscoveryInterface, gate *resourceGate) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		// the manager is shutting down if the poll is cancelled
+		_ = wait.PollUntilContextCancel(ctx, crdPollInterval, false, func(ctx context.Context) (bool, error) {
+			done, err := gate.openServed(dc)
+			if err != nil {
+				klog.Warningf("failed to discover Stash resources, reason: %v", err)
+				return false, nil
+			}
+			return done, nil
+		})
+		return nil
+	})
+}
+
+func joinResources(gvrs []schema.GroupVersionResource) string {
+	names := make([]string, 0, len(gvrs))
+	for _, gvr := range gvrs {
+		names = append(names, gvr.GroupResource().String())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,140 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"stash.appscode.dev/apimachinery/apis/ui"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newFakeDiscovery(resources ...*metav1.APIResourceList) *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: resources}}
+}
+
+// stashResources lists the stash.appscode.com/v1beta1 resources served by the fake discovery
+func stashResources(names ...string) *metav1.APIResourceList {
+	list := &metav1.APIResourceList{GroupVersion: backupConfigurations.GroupVersion().String()}
+	for _, name := range names {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: name})
+	}
+	return list
+}
+
+func TestMissingResources(t *testing.T) {
+	// v1alpha1 is not served at all, v1beta1 lacks BackupBatches
+	dc := newFakeDiscovery(stashResources(backupConfigurations.Resource, backupSessions.Resource))
+
+	missing, err := missingResources(dc, backupConfigurations, backupBatches, backupSessions, repositories)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []schema.GroupVersionResource{backupBatches, repositories}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("missingResources() = %v, want %v", missing, want)
+	}
+	// each group version is discovered once
+	if n := len(dc.Actions()); n != 2 {
+		t.Errorf("discovered %d times, want 2", n)
+	}
+}
+
+func TestMissingResourcesError(t *testing.T) {
+	dc := newFakeDiscovery()
+	dc.PrependReactor("*", "*", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	if _, err := missingResources(dc, backupConfigurations); err == nil {
+		t.Error("expected the discovery error")
+	}
+}
+
+func TestStashResourcesCheck(t *testing.T) {
+	dc := newFakeDiscovery(stashResources(backupConfigurations.Resource, backupSessions.Resource))
+	c := newStashResourcesCheck(dc)
+
+	if err := c.Check(nil); err == nil {
+		t.Fatal("expected repositories to be missing")
+	}
+	if err := c.Check(nil); err == nil {
+		t.Fatal("expected the cached failure")
+	}
+	if n := len(dc.Actions()); n != 2 {
+		t.Errorf("discovered %d times, want 2", n)
+	}
+
+	dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+		GroupVersion: repositories.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: repositories.Resource}},
+	})
+	c.ttl = 0
+	if err := c.Check(nil); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestResourceGate(t *testing.T) {
+	dc := newFakeDiscovery(stashResources(backupConfigurations.Resource))
+	gate := newResourceGate()
+	gate.close("backupbatchoverviews", []schema.GroupVersionResource{backupBatches})
+
+	h := gate.withGate(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(resource string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(apirequest.WithRequestInfo(req.Context(), &apirequest.RequestInfo{
+			IsResourceRequest: true,
+			Verb:              "list",
+			APIGroup:          ui.GroupName,
+			APIVersion:        "v1alpha1",
+			Resource:          resource,
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := serve("backupbatchoverviews"); code != http.StatusServiceUnavailable {
+		t.Errorf("closed resource served with %d, want 503", code)
+	}
+	if code := serve("backupoverviews"); code != http.StatusOK {
+		t.Errorf("open resource served with %d, want 200", code)
+	}
+
+	if done, err := gate.openServed(dc); err != nil || done {
+		t.Fatalf("openServed() = %v, %v, want the resource to stay closed", done, err)
+	}
+	dc.Resources = []*metav1.APIResourceList{stashResources(backupConfigurations.Resource, backupBatches.Resource)}
+	if done, err := gate.openServed(dc); err != nil || !done {
+		t.Fatalf("openServed() = %v, %v, want the resource to be opened", done, err)
+	}
+	if code := serve("backupbatchoverviews"); code != http.StatusOK {
+		t.Errorf("opened resource served with %d, want 200", code)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+	"stash.appscode.dev/ui-server/pkg/notification"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// enabled is true if the evaluator runs and has a sink to report to
+func (c EvaluatorConfig) enabled() bool {
+	if c.Interval <= 0 {
+		return false
+	}
+	if c.NotificationConfig != "" {
+		return true
+	}
+	for _, enabled := range c.EventConditions {
+		if enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// setup adds the evaluator along with its sinks to the manager. Nothing is
+// added if the evaluator is disabled or no sink is configured.
+func (c EvaluatorConfig) setup(mgr manager.Manager, kc client.Client, cluster string) error {
+	if !c.enabled() {
+		return nil
+	}
+
+	var sinks []evaluator.Sink
+	if c.NotificationConfig != "" {
+		cfg, err := notification.LoadConfig(c.NotificationConfig)
+		if err != nil {
+			return err
+		}
+		n := notification.NewNotifier(cfg, cluster, c.NotificationDedupWindow)
+		if err := mgr.Add(n); err != nil {
+			return err
+		}
+		sinks = append(sinks, n)
+	}
+	for _, enabled := range c.EventConditions {
+		if enabled {
+			sinks = append(sinks, evaluator.NewEventSink(mgr.GetEventRecorderFor("stash-ui-server"), c.EventConditions, c.EventQPS, c.EventBurst))
+			break
+		}
+	}
+	return mgr.Add(evaluator.New(kc, evaluator.Options{
+		Interval:         c.Interval,
+		PausedAlertAfter: c.PausedAlertAfter,
+		APIReader:        mgr.GetAPIReader(),
+	}, sinks...))
+}
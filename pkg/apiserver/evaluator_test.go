@@ -0,0 +1,42 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+	"time"
+
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+)
+
+func TestEvaluatorConfigEnabled(t *testing.T) {
+	cases := []struct {
+		name string
+		c    EvaluatorConfig
+		want bool
+	}{
+		{"no interval", EvaluatorConfig{NotificationConfig: "webhooks.yaml"}, false},
+		{"no sink", EvaluatorConfig{Interval: time.Minute, EventConditions: map[evaluator.Condition]bool{evaluator.ConditionOverdue: false}}, false},
+		{"webhooks", EvaluatorConfig{Interval: time.Minute, NotificationConfig: "webhooks.yaml"}, true},
+		{"events", EvaluatorConfig{Interval: time.Minute, EventConditions: map[evaluator.Condition]bool{evaluator.ConditionOverdue: true}}, true},
+	}
+	for _, c := range cases {
+		if got := c.c.enabled(); got != c.want {
+			t.Errorf("%s: enabled() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"stash.appscode.dev/ui-server/pkg/history"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// setup opens the history store and adds its recorder to the manager. It
+// returns nil if the history is disabled.
+func (c HistoryConfig) setup(mgr manager.Manager, kc client.Client) (*history.Store, error) {
+	if c.Dir == "" {
+		return nil, nil
+	}
+
+	store, err := history.Open(c.Dir, c.Retention)
+	if err != nil {
+		return nil, err
+	}
+	if err := mgr.Add(history.NewRecorder(kc, store, c.Interval)); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
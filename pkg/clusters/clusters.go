@@ -0,0 +1,224 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// Timeout bounds every request sent to a member cluster, so that an
+	// unreachable cluster can not stall the aggregated view.
+	Timeout = 10 * time.Second
+
+	probeInterval = 30 * time.Second
+)
+
+// Member is a cluster whose backups are aggregated next to the local ones
+type Member struct {
+	Name    string
+	Context string
+	Local   bool
+	Client  client.Client
+
+	mu     sync.RWMutex
+	status uisrv.MemberClusterStatus
+}
+
+// ParseMember parses a member cluster given as NAME=KUBECONFIG[:CONTEXT]
+func ParseMember(s string) (name, kubeconfig, kubeContext string, err error) {
+	name, rest, ok := strings.Cut(s, "=")
+	if !ok || rest == "" {
+		return "", "", "", fmt.Errorf("member cluster %q is not of the form NAME=KUBECONFIG[:CONTEXT]", s)
+	}
+	if err := ValidateName(name); err != nil {
+		return "", "", "", fmt.Errorf("invalid member cluster name: %v", err)
+	}
+	kubeconfig, kubeContext, _ = strings.Cut(rest, ":")
+	if kubeconfig == "" {
+		return "", "", "", fmt.Errorf("member cluster %q is missing the kubeconfig", name)
+	}
+	return name, kubeconfig, kubeContext, nil
+}
+
+// ValidateName checks that name is a DNS-1123 label. The names of aggregated
+// objects are of the form CLUSTER.NAMESPACE.NAME, so a cluster name must not
+// contain a dot.
+func ValidateName(name string) error {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("%q: %s", name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// IsUnreachable reports whether err means that a member cluster could not be
+// reached, as opposed to a request that the member answered with an error.
+// A cancelled request says nothing about the member.
+func IsUnreachable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err)
+}
+
+// NewLocal returns the member for the cluster the server runs in
+func NewLocal(name string, kc client.Client) *Member {
+	return &Member{
+		Name:   name,
+		Local:  true,
+		Client: kc,
+		status: uisrv.MemberClusterStatus{Phase: uisrv.MemberClusterUnknown},
+	}
+}
+
+// NewMember returns a member cluster reached via the given kubeconfig and context.
+// The current context is used if kubeContext is empty.
+func NewMember(name, kubeconfig, kubeContext string, scheme *runtime.Scheme) (*Member, error) {
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext},
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig for member cluster %s, reason: %v", name, err)
+	}
+	cfg.Timeout = Timeout
+
+	kc, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for member cluster %s, reason: %v", name, err)
+	}
+	return &Member{
+		Name:    name,
+		Context: kubeContext,
+		Client:  kc,
+		status:  uisrv.MemberClusterStatus{Phase: uisrv.MemberClusterUnknown},
+	}, nil
+}
+
+// Status returns the outcome of the latest health probe
+func (m *Member) Status() uisrv.MemberClusterStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return *m.status.DeepCopy()
+}
+
+// Reachable is false if the latest probe or request failed. Members that were
+// not probed yet are considered reachable.
+func (m *Member) Reachable() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status.Phase != uisrv.MemberClusterUnreachable
+}
+
+// MarkUnreachable records a failed request, so that the member is skipped
+// until the next successful probe.
+func (m *Member) MarkUnreachable(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status.Phase = uisrv.MemberClusterUnreachable
+	m.status.Message = err.Error()
+}
+
+// Probe lists the BackupConfigurations of the member and records the outcome
+func (m *Member) Probe(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	var list stashv1beta1.BackupConfigurationList
+	err := m.Client.List(ctx, &list)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status.LastProbeTime = &metav1.Time{Time: time.Now()}
+	if err != nil {
+		if m.status.Phase != uisrv.MemberClusterUnreachable {
+			klog.Warningf("member cluster %s is unreachable, reason: %v", m.Name, err)
+		}
+		m.status.Phase = uisrv.MemberClusterUnreachable
+		m.status.Message = err.Error()
+		return
+	}
+	m.status.Phase = uisrv.MemberClusterHealthy
+	m.status.Message = ""
+	m.status.BackupConfigurations = len(list.Items)
+}
+
+// Set is the local cluster along with its member clusters
+type Set struct {
+	members []*Member
+}
+
+var _ manager.Runnable = &Set{}
+
+// NewSet returns a set of the local cluster followed by the given members
+func NewSet(local *Member, members ...*Member) *Set {
+	return &Set{members: append([]*Member{local}, members...)}
+}
+
+// Members returns all clusters of the set, the local cluster first
+func (s *Set) Members() []*Member {
+	return s.members
+}
+
+// Get returns the member with the given name, or nil if there is none
+func (s *Set) Get(name string) *Member {
+	for _, m := range s.members {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Start probes the members periodically until the context is done
+func (s *Set) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		var wg sync.WaitGroup
+		for _, m := range s.members {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.Probe(ctx)
+			}()
+		}
+		wg.Wait()
+	}, probeInterval)
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseMember(t *testing.T) {
+	cases := []struct {
+		in          string
+		name        string
+		kubeconfig  string
+		kubeContext string
+		err         bool
+	}{
+		{"prod=/etc/kube/prod.yaml", "prod", "/etc/kube/prod.yaml", "", false},
+		{"dr=/etc/kube/config:dr-admin", "dr", "/etc/kube/config", "dr-admin", false},
+		{"/etc/kube/config", "", "", "", true},
+		{"prod=", "", "", "", true},
+		{"Prod.EU=/etc/kube/config", "", "", "", true},
+		{"eu.prod=/etc/kube/config", "", "", "", true},
+	}
+	for _, c := range cases {
+		name, kubeconfig, kubeContext, err := ParseMember(c.in)
+		if (err != nil) != c.err {
+			t.Errorf("ParseMember(%q) error = %v, expected error %v", c.in, err, c.err)
+			continue
+		}
+		if name != c.name || kubeconfig != c.kubeconfig || kubeContext != c.kubeContext {
+			t.Errorf("ParseMember(%q) = %q, %q, %q, expected %q, %q, %q", c.in, name, kubeconfig, kubeContext, c.name, c.kubeconfig, c.kubeContext)
+		}
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{refused, true},
+		{fmt.Errorf("list failed: %w", context.DeadlineExceeded), true},
+		{context.Canceled, false},
+		{apierrors.NewForbidden(schema.GroupResource{Resource: "backupconfigurations"}, "", errors.New("denied")), false},
+		{errors.New("no kind is registered"), false},
+	}
+	for _, c := range cases {
+		if got := IsUnreachable(c.err); got != c.want {
+			t.Errorf("IsUnreachable(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
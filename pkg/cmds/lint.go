@@ -0,0 +1,82 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmds
+
+import (
+	"fmt"
+	"io"
+
+	"stash.appscode.dev/ui-server/pkg/lint"
+
+	"github.com/spf13/cobra"
+)
+
+const outputSARIF = "sarif"
+
+func NewCmdLint(out io.Writer) *cobra.Command {
+	var (
+		output = outputJSON
+		opts   lint.Options
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint <dir>",
+		Short: "Check Stash manifests without a cluster",
+		Long: `Check the BackupConfigurations, BackupBatches, BackupBlueprints and Repositories below a directory
+for invalid schedules, missing repositories, incomplete retention policies, overlapping targets and
+usage policy violations. The command fails if any error is found.`,
+		Args:              cobra.ExactArgs(1),
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var write func(io.Writer, []lint.Finding) error
+			switch output {
+			case outputJSON:
+				write = lint.WriteJSON
+			case outputSARIF:
+				write = lint.WriteSARIF
+			default:
+				return fmt.Errorf("unknown output format %q, must be one of: json, sarif", output)
+			}
+
+			findings, err := lint.Lint(args[0], opts)
+			if err != nil {
+				return err
+			}
+			if err := write(out, findings); err != nil {
+				return err
+			}
+
+			errs := 0
+			for _, f := range findings {
+				if f.Level == lint.LevelError {
+					errs++
+				}
+			}
+			if errs > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("found %d errors in %s", errs, args[0])
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&output, "output", "o", output, "Output format. One of: json, sarif")
+	flags.StringVar(&opts.DefaultNamespace, "default-namespace", "default", "The namespace assumed for manifests without one")
+
+	return cmd
+}
@@ -0,0 +1,200 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmds
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputCSV      = "csv"
+	outputMarkdown = "markdown"
+)
+
+var overviewColumns = []string{
+	"NAMESPACE", "NAME", "SCHEDULE", "STATUS", "PHASE", "LAST BACKUP", "UPCOMING BACKUP",
+	"REPOSITORY", "DATA SIZE", "SNAPSHOTS", "INTEGRITY",
+}
+
+type overviewOptions struct {
+	kubeconfigOptions
+
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+	Output        string
+}
+
+func NewCmdOverview(ctx context.Context, out, errOut io.Writer) *cobra.Command {
+	o := &overviewOptions{
+		Namespace: metav1.NamespaceDefault,
+		Output:    outputTable,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "overview",
+		Short:             "Print the backup overviews of a cluster",
+		Long:              "Print the backup overviews of a namespace or all namespaces as a table, JSON, CSV or Markdown",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(ctx, out, errOut)
+		},
+	}
+
+	flags := cmd.Flags()
+	o.kubeconfigOptions.AddFlags(flags)
+	flags.StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "The namespace of the BackupConfigurations")
+	flags.BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "Print the overviews of all namespaces")
+	flags.StringVarP(&o.Selector, "selector", "l", o.Selector, "Label selector of the BackupConfigurations")
+	flags.StringVarP(&o.Output, "output", "o", o.Output, "Output format. One of: table, json, csv, markdown")
+
+	return cmd
+}
+
+func (o *overviewOptions) Run(ctx context.Context, out, errOut io.Writer) error {
+	switch o.Output {
+	case outputTable, outputJSON, outputCSV, outputMarkdown:
+	default:
+		return fmt.Errorf("unknown output format %q, must be one of: table, json, csv, markdown", o.Output)
+	}
+	sel, err := labels.Parse(o.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector %q, reason: %v", o.Selector, err)
+	}
+
+	kc, err := o.Client()
+	if err != nil {
+		return err
+	}
+
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: sel}}
+	if !o.AllNamespaces {
+		opts = append(opts, client.InNamespace(o.Namespace))
+	}
+	var cfgs stashv1beta1.BackupConfigurationList
+	if err := kc.List(ctx, &cfgs, opts...); err != nil {
+		return err
+	}
+
+	list := uiapi.BackupOverviewList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: uiapi.SchemeGroupVersion.String(),
+			Kind:       uiapi.ResourceKindBackupOverview + "List",
+		},
+	}
+	overviews := backups.NewOverviewBuilder(kc)
+	for i := range cfgs.Items {
+		bo, err := overviews.Build(ctx, &cfgs.Items[i])
+		if err != nil {
+			// a broken configuration must not hide the others from the report
+			_, _ = fmt.Fprintf(errOut, "warning: skipping BackupConfiguration %s/%s: %v\n", cfgs.Items[i].Namespace, cfgs.Items[i].Name, err)
+			continue
+		}
+		bo.APIVersion = uiapi.SchemeGroupVersion.String()
+		bo.Kind = uiapi.ResourceKindBackupOverview
+		list.Items = append(list.Items, *bo)
+	}
+
+	return writeOverviews(out, o.Output, list)
+}
+
+func writeOverviews(w io.Writer, format string, list uiapi.BackupOverviewList) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(overviewColumns); err != nil {
+			return err
+		}
+		for _, bo := range list.Items {
+			if err := cw.Write(overviewRow(bo)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case outputMarkdown:
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(overviewColumns, " | ")); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(overviewColumns))); err != nil {
+			return err
+		}
+		for _, bo := range list.Items {
+			row := overviewRow(bo)
+			for i := range row {
+				row[i] = strings.ReplaceAll(row[i], "|", `\|`)
+			}
+			if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(row, " | ")); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+		_, _ = fmt.Fprintln(tw, strings.Join(overviewColumns, "\t"))
+		for _, bo := range list.Items {
+			_, _ = fmt.Fprintln(tw, strings.Join(overviewRow(bo), "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+func overviewRow(bo uiapi.BackupOverview) []string {
+	return []string{
+		bo.Namespace,
+		bo.Name,
+		bo.Spec.Schedule,
+		string(bo.Spec.Status),
+		string(bo.Status.Phase),
+		formatTime(bo.Spec.LastBackupTime),
+		formatTime(bo.Spec.UpcomingBackupTime),
+		bo.Spec.Repository,
+		bo.Spec.DataSize,
+		strconv.FormatInt(bo.Spec.NumberOfSnapshots, 10),
+		strconv.FormatBool(bo.Spec.DataIntegrity),
+	}
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmds
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriteOverviews(t *testing.T) {
+	list := uiapi.BackupOverviewList{
+		Items: []uiapi.BackupOverview{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "mysql"},
+				Spec: uiapi.BackupOverviewSpec{
+					Schedule:          `"*/5 * * * *" (Every 5 minutes)`,
+					Status:            uiapi.BackupStatusActive,
+					Repository:        "mysql-repo",
+					NumberOfSnapshots: 3,
+					DataIntegrity:     true,
+				},
+			},
+		},
+	}
+
+	cases := map[string]string{
+		outputCSV:      `demo,mysql,"""*/5 * * * *"" (Every 5 minutes)",Active,,,,mysql-repo,,3,true`,
+		outputMarkdown: `| demo | mysql | "*/5 * * * *" (Every 5 minutes) | Active |  |  |  | mysql-repo |  | 3 | true |`,
+	}
+	for format, expected := range cases {
+		var buf bytes.Buffer
+		if err := writeOverviews(&buf, format, list); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if got := lines[len(lines)-1]; got != expected {
+			t.Errorf("%s row = %s, expected %s", format, got, expected)
+		}
+	}
+}
@@ -34,6 +34,11 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(v.NewCmdVersion())
 	ctx := genericapiserver.SetupSignalContext()
 	rootCmd.AddCommand(NewCmdRun(ctx, os.Stdout, os.Stderr))
+	rootCmd.AddCommand(NewCmdOverview(ctx, os.Stdout, os.Stderr))
+	rootCmd.AddCommand(NewCmdLint(os.Stdout))
+	rootCmd.AddCommand(NewCmdSupportBundle(ctx, os.Stdout))
+	rootCmd.AddCommand(NewCmdSLOReport(ctx, os.Stdout))
+	rootCmd.AddCommand(NewCmdServeLocal(ctx, os.Stdout, os.Stderr))
 
 	return rootCmd
 }
@@ -54,6 +54,7 @@ func NewCmdRun(ctx context.Context, out, errOut io.Writer) *cobra.Command {
 
 	flags := cmd.Flags()
 	o.RecommendedOptions.AddFlags(flags)
+	o.ExtraOptions.AddFlags(flags)
 	utilfeature.DefaultMutableFeatureGate.AddFlag(flags)
 
 	return cmd
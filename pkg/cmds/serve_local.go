@@ -0,0 +1,52 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmds
+
+import (
+	"context"
+	"io"
+
+	"stash.appscode.dev/ui-server/pkg/cmds/server"
+
+	"github.com/spf13/cobra"
+	v "gomodules.xyz/x/version"
+	"k8s.io/klog/v2"
+)
+
+func NewCmdServeLocal(ctx context.Context, out, errOut io.Writer) *cobra.Command {
+	o := server.NewLocalServerOptions(out, errOut)
+
+	cmd := &cobra.Command{
+		Use:   "serve-local",
+		Short: "Serve the UI API locally using a kubeconfig",
+		Long: `Serve the ui.stash.appscode.com API over plain HTTP(S) without registering an APIService.
+Requests are not authenticated. They are served and authorized as the user of the kubeconfig.`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			klog.Infof("Starting local ui server version %s+%s ...", v.Version.Version, v.Version.CommitHash)
+
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.RunLocalServer(ctx)
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,327 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"stash.appscode.dev/ui-server/pkg/apiserver"
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	"github.com/spf13/pflag"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	authorizationclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const localShutdownTimeout = 10 * time.Second
+
+// LocalServerOptions contains state for a UI server that runs outside of the
+// cluster without API aggregation, e.g. next to a frontend under development.
+// Every request is served as the identity of the kubeconfig.
+type LocalServerOptions struct {
+	Kubeconfig  string
+	Context     string
+	BindAddress string
+	Port        int
+	CertFile    string
+	KeyFile     string
+	// AllowRemote permits a non-loopback BindAddress. Anyone who can reach it
+	// acts as the identity of the kubeconfig.
+	AllowRemote bool
+
+	ExtraOptions *ExtraOptions
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewLocalServerOptions returns a new LocalServerOptions. A local server is
+// usually one of many next to the in-cluster one, so it records no Events
+// unless asked to by flag. Like in the cluster, the evaluator is off as long as
+// --evaluation-interval is not set and the history as long as --history-dir
+// is not set.
+func NewLocalServerOptions(out, errOut io.Writer) *LocalServerOptions {
+	extra := NewExtraOptions()
+	extra.eventDefaults = map[evaluator.Condition]bool{}
+	for c := range evaluator.EventConditions {
+		extra.eventDefaults[c] = false
+	}
+	return &LocalServerOptions{
+		BindAddress:  "127.0.0.1",
+		Port:         8080,
+		ExtraOptions: extra,
+		StdOut:       out,
+		StdErr:       errOut,
+	}
+}
+
+func (o *LocalServerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file. The default loading rules are used if empty.")
+	fs.StringVar(&o.Context, "context", o.Context, "The kubeconfig context to use")
+	fs.StringVar(&o.BindAddress, "bind-address", o.BindAddress, "The IP address on which to listen. Only loopback addresses are allowed unless --insecure-allow-remote is set.")
+	fs.BoolVar(&o.AllowRemote, "insecure-allow-remote", o.AllowRemote, "Allow a non-loopback --bind-address. Every client that can reach it is served as the identity of the kubeconfig.")
+	fs.IntVar(&o.Port, "port", o.Port, "The port on which to serve")
+	fs.StringVar(&o.CertFile, "tls-cert-file", o.CertFile, "File containing the x509 certificate for HTTPS. Plain HTTP is served if empty.")
+	fs.StringVar(&o.KeyFile, "tls-private-key-file", o.KeyFile, "File containing the x509 private key matching --tls-cert-file")
+	o.ExtraOptions.AddFlags(fs)
+}
+
+// Validate validates LocalServerOptions
+func (o LocalServerOptions) Validate(args []string) error {
+	var errors []error
+	if ip := net.ParseIP(o.BindAddress); ip == nil {
+		errors = append(errors, fmt.Errorf("--bind-address %q is not a valid IP address", o.BindAddress))
+	} else if !ip.IsLoopback() && !o.AllowRemote {
+		errors = append(errors, fmt.Errorf("--bind-address %q is not a loopback address, set --insecure-allow-remote to serve it", o.BindAddress))
+	}
+	if o.Port < 1 || o.Port > 65535 {
+		errors = append(errors, fmt.Errorf("--port %d must be between 1 and 65535", o.Port))
+	}
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		errors = append(errors, fmt.Errorf("--tls-cert-file and --tls-private-key-file must be set together"))
+	}
+	errors = append(errors, o.ExtraOptions.Validate()...)
+	return utilerrors.NewAggregate(errors)
+}
+
+func (o *LocalServerOptions) address() string {
+	return net.JoinHostPort(o.BindAddress, strconv.Itoa(o.Port))
+}
+
+// Config returns config for the api server given LocalServerOptions
+func (o *LocalServerOptions) Config(ctx context.Context) (*apiserver.Config, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.Kubeconfig
+	clientConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		rules,
+		&clientcmd.ConfigOverrides{CurrentContext: o.Context},
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig, reason: %v", err)
+	}
+	if err := o.ExtraOptions.ApplyTo(clientConfig); err != nil {
+		return nil, err
+	}
+
+	kc, err := kubernetes.NewForConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := kubeconfigIdentity(ctx, kc)
+	if err != nil {
+		return nil, err
+	}
+	// the requests are served as the kubeconfig identity, so the writes are
+	// sent as is instead of impersonating it
+	writer, err := client.New(clientConfig, client.Options{Scheme: apiserver.Scheme})
+	if err != nil {
+		return nil, err
+	}
+	klog.Infof("serving all requests as user %q with groups %v", identity.GetName(), identity.GetGroups())
+
+	scheme := "http"
+	if o.CertFile != "" {
+		scheme = "https"
+	}
+
+	serverConfig := genericapiserver.NewRecommendedConfig(apiserver.Codecs)
+	serverConfig.ClientConfig = clientConfig
+	serverConfig.SharedInformerFactory = informers.NewSharedInformerFactory(kc, 10*time.Minute)
+	serverConfig.ExternalAddress = o.address()
+	// there is no secure port to loop back to, this only satisfies the generic apiserver
+	serverConfig.LoopbackClientConfig = &restclient.Config{Host: scheme + "://" + o.address()}
+	serverConfig.Authentication.Authenticator = authenticator.RequestFunc(func(_ *http.Request) (*authenticator.Response, bool, error) {
+		return &authenticator.Response{User: identity}, true, nil
+	})
+	// the storages check every request against the kubeconfig identity
+	serverConfig.Authorization.Authorizer = authorizerfactory.NewAlwaysAllowAuthorizer()
+	configureServer(serverConfig)
+
+	config := &apiserver.Config{
+		GenericConfig: serverConfig,
+		ExtraConfig: apiserver.ExtraConfig{
+			ClientConfig:       clientConfig,
+			MetricsBindAddress: o.ExtraOptions.MetricsBindAddress,
+			ClusterName:        o.ExtraOptions.ClusterName,
+			MemberClusters:     o.ExtraOptions.MemberClusters,
+			Authorizer:         &selfAuthorizer{client: kc.AuthorizationV1().SelfSubjectAccessReviews()},
+			UserClient:         shared.DirectClient(writer),
+			Evaluator: apiserver.EvaluatorConfig{
+				Interval:                o.ExtraOptions.EvaluationInterval,
+				PausedAlertAfter:        o.ExtraOptions.PausedAlertAfter,
+				NotificationConfig:      o.ExtraOptions.NotificationConfig,
+				NotificationDedupWindow: o.ExtraOptions.NotificationDedupWindow,
+				EventConditions:         o.ExtraOptions.EventConditionsEnabled(),
+				EventQPS:                o.ExtraOptions.EventQPS,
+				EventBurst:              o.ExtraOptions.EventBurst,
+			},
+			History: apiserver.HistoryConfig{
+				Dir:       o.ExtraOptions.HistoryDir,
+				Interval:  o.ExtraOptions.HistoryInterval,
+				Retention: o.ExtraOptions.HistoryRetention,
+			},
+		},
+	}
+	return config, nil
+}
+
+// RunLocalServer starts a new UIServer given LocalServerOptions
+func (o LocalServerOptions) RunLocalServer(ctx context.Context) error {
+	config, err := o.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	server, err := config.Complete().New(ctx)
+	if err != nil {
+		return err
+	}
+
+	server.GenericAPIServer.AddPostStartHookOrDie("start-ui-server-informers", func(context genericapiserver.PostStartHookContext) error {
+		config.GenericConfig.SharedInformerFactory.Start(context.Done())
+		return nil
+	})
+
+	prepared := server.GenericAPIServer.PrepareRun()
+	err = server.Manager.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		// without secure serving this only runs the post start hooks
+		if _, _, err := prepared.NonBlockingRunWithContext(ctx, localShutdownTimeout); err != nil {
+			return err
+		}
+		return o.serve(ctx, server.GenericAPIServer.Handler)
+	}))
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(o.BindAddress); ip != nil && !ip.IsLoopback() {
+		klog.Warningf("serving %s to remote clients as the identity of the kubeconfig", o.address())
+	}
+	klog.Infof("starting local ui server on %s", o.address())
+	return server.Manager.Start(ctx)
+}
+
+func (o LocalServerOptions) serve(ctx context.Context, handler http.Handler) error {
+	srv := &http.Server{
+		Addr:              o.address(),
+		Handler:           handler,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), localShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			klog.Errorf("failed to shut down local ui server, reason: %v", err)
+		}
+	}()
+
+	var err error
+	if o.CertFile != "" {
+		err = srv.ListenAndServeTLS(o.CertFile, o.KeyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// kubeconfigIdentity asks the cluster who the kubeconfig authenticates as
+func kubeconfigIdentity(ctx context.Context, kc kubernetes.Interface) (user.Info, error) {
+	review, err := kc.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine the user of the kubeconfig, reason: %v", err)
+	}
+	info := review.Status.UserInfo
+	extra := make(map[string][]string, len(info.Extra))
+	for k, v := range info.Extra {
+		extra[k] = v
+	}
+	return &user.DefaultInfo{
+		Name:   info.Username,
+		UID:    info.UID,
+		Groups: info.Groups,
+		Extra:  extra,
+	}, nil
+}
+
+// selfAuthorizer authorizes every request as the identity of the kubeconfig
+// using SelfSubjectAccessReviews, so the server can never do more than the
+// kubeconfig itself. The user of the request is ignored.
+type selfAuthorizer struct {
+	client authorizationclient.SelfSubjectAccessReviewInterface
+}
+
+var _ authorizer.Authorizer = &selfAuthorizer{}
+
+func (a *selfAuthorizer) Authorize(ctx context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{}
+	if attrs.IsResourceRequest() {
+		review.Spec.ResourceAttributes = &authorizationv1.ResourceAttributes{
+			Namespace:   attrs.GetNamespace(),
+			Verb:        attrs.GetVerb(),
+			Group:       attrs.GetAPIGroup(),
+			Version:     attrs.GetAPIVersion(),
+			Resource:    attrs.GetResource(),
+			Subresource: attrs.GetSubresource(),
+			Name:        attrs.GetName(),
+		}
+	} else {
+		review.Spec.NonResourceAttributes = &authorizationv1.NonResourceAttributes{
+			Path: attrs.GetPath(),
+			Verb: attrs.GetVerb(),
+		}
+	}
+
+	result, err := a.client.Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+	switch {
+	case result.Status.Allowed:
+		return authorizer.DecisionAllow, result.Status.Reason, nil
+	case result.Status.Denied:
+		return authorizer.DecisionDeny, result.Status.Reason, nil
+	default:
+		return authorizer.DecisionNoOpinion, result.Status.Reason, nil
+	}
+}
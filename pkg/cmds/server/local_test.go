@@ -0,0 +1,71 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"io"
+	"testing"
+
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+
+	"github.com/spf13/pflag"
+)
+
+func TestLocalServerBindAddress(t *testing.T) {
+	cases := []struct {
+		address     string
+		allowRemote bool
+		valid       bool
+	}{
+		{address: "127.0.0.1", valid: true},
+		{address: "::1", valid: true},
+		{address: "0.0.0.0"},
+		{address: "192.168.1.10"},
+		{address: "0.0.0.0", allowRemote: true, valid: true},
+		{address: "localhost", allowRemote: true},
+	}
+	for _, c := range cases {
+		o := NewLocalServerOptions(io.Discard, io.Discard)
+		o.BindAddress = c.address
+		o.AllowRemote = c.allowRemote
+		if err := o.Validate(nil); (err == nil) != c.valid {
+			t.Errorf("Validate() with --bind-address=%s --insecure-allow-remote=%v: %v", c.address, c.allowRemote, err)
+		}
+	}
+}
+
+func TestLocalServerDefaults(t *testing.T) {
+	o := NewLocalServerOptions(io.Discard, io.Discard)
+	fs := pflag.NewFlagSet("serve-local", pflag.ContinueOnError)
+	o.AddFlags(fs)
+	if o.ExtraOptions.EvaluationInterval != 0 || o.ExtraOptions.HistoryDir != "" {
+		t.Errorf("the evaluator and the history must be off, got --evaluation-interval=%s --history-dir=%q", o.ExtraOptions.EvaluationInterval, o.ExtraOptions.HistoryDir)
+	}
+	for c, enabled := range o.ExtraOptions.EventConditionsEnabled() {
+		if enabled {
+			t.Errorf("event condition %s must be off", c)
+		}
+	}
+
+	if err := fs.Parse([]string{"--event-conditions=" + string(evaluator.ConditionOverdue) + "=true"}); err != nil {
+		t.Fatal(err)
+	}
+	conditions := o.ExtraOptions.EventConditionsEnabled()
+	if !conditions[evaluator.ConditionOverdue] || conditions[evaluator.ConditionTargetGone] {
+		t.Errorf("only the event condition given by flag must be on, got %v", conditions)
+	}
+}
@@ -17,25 +17,139 @@ limitations under the License.
 package server
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"stash.appscode.dev/ui-server/pkg/clusters"
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
 	restclient "k8s.io/client-go/rest"
+	cliflag "k8s.io/component-base/cli/flag"
 )
 
 type ExtraOptions struct {
 	QPS   float64
 	Burst int
+
+	MetricsBindAddress string
+
+	ClusterName    string
+	MemberClusters []string
+
+	EvaluationInterval      time.Duration
+	PausedAlertAfter        time.Duration
+	NotificationConfig      string
+	NotificationDedupWindow time.Duration
+
+	EventConditions map[string]bool
+	EventQPS        float32
+	EventBurst      int
+	// eventDefaults are the event conditions enabled unless set by flag
+	eventDefaults map[evaluator.Condition]bool
+
+	HistoryDir       string
+	HistoryInterval  time.Duration
+	HistoryRetention time.Duration
 }
 
 func NewExtraOptions() *ExtraOptions {
 	return &ExtraOptions{
 		QPS:   1e6,
 		Burst: 1e6,
+
+		MetricsBindAddress: "0",
+
+		ClusterName: "local",
+
+		PausedAlertAfter:        7 * 24 * time.Hour,
+		NotificationDedupWindow: time.Hour,
+
+		EventConditions: map[string]bool{},
+		EventQPS:        0.01,
+		EventBurst:      5,
+		eventDefaults:   evaluator.EventConditions,
+
+		HistoryInterval:  15 * time.Minute,
+		HistoryRetention: 180 * 24 * time.Hour,
 	}
 }
 
 func (s *ExtraOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.Float64Var(&s.QPS, "qps", s.QPS, "The maximum QPS to the master from this client")
 	fs.IntVar(&s.Burst, "burst", s.Burst, "The maximum burst for throttle")
+	fs.StringVar(&s.MetricsBindAddress, "metrics-bind-address", s.MetricsBindAddress, "The address the metrics endpoint binds to. Set to 0 to disable it.")
+	fs.StringVar(&s.ClusterName, "cluster-name", s.ClusterName, "The name of the local cluster in the aggregated views")
+	fs.StringArrayVar(&s.MemberClusters, "member-cluster", s.MemberClusters, "A member cluster whose backups are aggregated next to the local ones, as NAME=KUBECONFIG[:CONTEXT]. Can be repeated.")
+	fs.DurationVar(&s.EvaluationInterval, "evaluation-interval", s.EvaluationInterval, "How often the backup state is evaluated for notifications and Events, e.g. 1m. The evaluator is disabled if 0.")
+	fs.DurationVar(&s.PausedAlertAfter, "paused-alert-after", s.PausedAlertAfter, "Report BackupConfigurations that stay paused for longer than this")
+	fs.StringVar(&s.NotificationConfig, "notification-config", s.NotificationConfig, "Path to the file listing the webhooks notified about backup state transitions")
+	fs.DurationVar(&s.NotificationDedupWindow, "notification-dedup-window", s.NotificationDedupWindow, "The same notification is sent to a webhook at most once within this window")
+	fs.Var(cliflag.NewMapStringBool(&s.EventConditions), "event-conditions", "A set of key=value pairs that enable or disable the Events recorded on BackupConfigurations. "+
+		"Options are:\n"+strings.Join(s.eventConditionOptions(), "\n"))
+	fs.Float32Var(&s.EventQPS, "event-qps", s.EventQPS, "The maximum number of Events recorded per second for a condition of a BackupConfiguration")
+	fs.IntVar(&s.EventBurst, "event-burst", s.EventBurst, "The maximum burst of recorded Events for a condition of a BackupConfiguration")
+	fs.StringVar(&s.HistoryDir, "history-dir", s.HistoryDir, "Directory on a persistent volume to store the backup history in. The history is disabled if empty.")
+	fs.DurationVar(&s.HistoryInterval, "history-interval", s.HistoryInterval, "Interval between two records of the backup history")
+	fs.DurationVar(&s.HistoryRetention, "history-retention", s.HistoryRetention, "The backup history is kept for this long")
+}
+
+func (s *ExtraOptions) eventConditionOptions() []string {
+	var opts []string
+	for c, enabled := range s.eventDefaults {
+		opts = append(opts, fmt.Sprintf("%s=true|false (default=%t)", c, enabled))
+	}
+	sort.Strings(opts)
+	return opts
+}
+
+// EventConditionsEnabled merges the event conditions given by flag into the defaults
+func (s *ExtraOptions) EventConditionsEnabled() map[evaluator.Condition]bool {
+	conditions := map[evaluator.Condition]bool{}
+	for c, enabled := range s.eventDefaults {
+		conditions[c] = enabled
+	}
+	for c, enabled := range s.EventConditions {
+		conditions[evaluator.Condition(c)] = enabled
+	}
+	return conditions
+}
+
+func (s *ExtraOptions) Validate() []error {
+	var errs []error
+	if err := clusters.ValidateName(s.ClusterName); err != nil {
+		errs = append(errs, fmt.Errorf("invalid cluster name: %v", err))
+	}
+	names := sets.New(s.ClusterName)
+	for _, m := range s.MemberClusters {
+		name, _, _, err := clusters.ParseMember(m)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if names.Has(name) {
+			errs = append(errs, fmt.Errorf("duplicate cluster name %q", name))
+		}
+		names.Insert(name)
+	}
+	for c := range s.EventConditions {
+		if _, ok := evaluator.EventConditions[evaluator.Condition(c)]; !ok {
+			errs = append(errs, fmt.Errorf("unknown event condition %q", c))
+		}
+	}
+	if s.EventQPS <= 0 || s.EventBurst <= 0 {
+		errs = append(errs, fmt.Errorf("--event-qps and --event-burst must be positive"))
+	}
+	if s.HistoryDir != "" && (s.HistoryInterval <= 0 || s.HistoryRetention <= 0) {
+		errs = append(errs, fmt.Errorf("--history-interval and --history-retention must be positive"))
+	}
+	if s.EvaluationInterval < 0 {
+		errs = append(errs, fmt.Errorf("--evaluation-interval must not be negative"))
+	}
+	return errs
 }
 
 func (s *ExtraOptions) ApplyTo(clientConfig *restclient.Config) error {
@@ -21,10 +21,14 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 
 	api "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
 	uiv1alpha1 "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
 	"stash.appscode.dev/ui-server/pkg/apiserver"
+	"stash.appscode.dev/ui-server/pkg/instrumentation"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
 
 	v "gomodules.xyz/x/version"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -69,6 +73,7 @@ func NewUIServerOptions(out, errOut io.Writer) *UIServerOptions {
 func (o UIServerOptions) Validate(args []string) error {
 	var errors []error
 	errors = append(errors, o.RecommendedOptions.Validate()...)
+	errors = append(errors, o.ExtraOptions.Validate()...)
 	return utilerrors.NewAggregate(errors)
 }
 
@@ -91,32 +96,7 @@ func (o *UIServerOptions) Config() (*apiserver.Config, error) {
 	// Fixes https://github.com/Azure/AKS/issues/522
 	clientcmd.Fix(serverConfig.ClientConfig)
 
-	ignorePrefixes := []string{
-		"/swaggerapi",
-		fmt.Sprintf("/apis/%s/%s", uiv1alpha1.SchemeGroupVersion, uiv1alpha1.ResourceBackupOverviews),
-	}
-
-	serverConfig.EffectiveVersion = basecompatibility.NewEffectiveVersionFromString("v1.0.0", "", "")
-
-	serverConfig.OpenAPIConfig = genericapiserver.DefaultOpenAPIConfig(
-		ou.GetDefinitions(
-			api.GetOpenAPIDefinitions,
-			uiv1alpha1.GetOpenAPIDefinitions,
-		),
-		openapi.NewDefinitionNamer(apiserver.Scheme))
-	serverConfig.OpenAPIConfig.Info.Title = "stash-ui-server"
-	serverConfig.OpenAPIConfig.Info.Version = v.Version.Version
-	serverConfig.OpenAPIConfig.IgnorePrefixes = ignorePrefixes
-
-	serverConfig.OpenAPIV3Config = genericapiserver.DefaultOpenAPIV3Config(
-		ou.GetDefinitions(
-			api.GetOpenAPIDefinitions,
-			uiv1alpha1.GetOpenAPIDefinitions,
-		),
-		openapi.NewDefinitionNamer(apiserver.Scheme))
-	serverConfig.OpenAPIV3Config.Info.Title = "stash-ui-server"
-	serverConfig.OpenAPIV3Config.Info.Version = v.Version.Version
-	serverConfig.OpenAPIV3Config.IgnorePrefixes = ignorePrefixes
+	configureServer(serverConfig)
 
 	if err := o.ExtraOptions.ApplyTo(serverConfig.ClientConfig); err != nil {
 		return nil, err
@@ -124,7 +104,27 @@ func (o *UIServerOptions) Config() (*apiserver.Config, error) {
 
 	config := &apiserver.Config{
 		GenericConfig: serverConfig,
-		ExtraConfig:   apiserver.ExtraConfig{ClientConfig: serverConfig.ClientConfig},
+		ExtraConfig: apiserver.ExtraConfig{
+			ClientConfig:       serverConfig.ClientConfig,
+			MetricsBindAddress: o.ExtraOptions.MetricsBindAddress,
+			ClusterName:        o.ExtraOptions.ClusterName,
+			MemberClusters:     o.ExtraOptions.MemberClusters,
+			Evaluator: apiserver.EvaluatorConfig{
+				Interval:                o.ExtraOptions.EvaluationInterval,
+				PausedAlertAfter:        o.ExtraOptions.PausedAlertAfter,
+				NotificationConfig:      o.ExtraOptions.NotificationConfig,
+				NotificationDedupWindow: o.ExtraOptions.NotificationDedupWindow,
+				EventConditions:         o.ExtraOptions.EventConditionsEnabled(),
+				EventQPS:                o.ExtraOptions.EventQPS,
+				EventBurst:              o.ExtraOptions.EventBurst,
+				LeaderElection:          true,
+			},
+			History: apiserver.HistoryConfig{
+				Dir:       o.ExtraOptions.HistoryDir,
+				Interval:  o.ExtraOptions.HistoryInterval,
+				Retention: o.ExtraOptions.HistoryRetention,
+			},
+		},
 	}
 	return config, nil
 }
@@ -157,3 +157,38 @@ func (o UIServerOptions) RunUIServer(ctx context.Context) error {
 	setupLog.Info("starting manager")
 	return server.Manager.Start(ctx)
 }
+
+// configureServer sets up the handler chain and the OpenAPI spec shared by all modes of the server
+func configureServer(serverConfig *genericapiserver.RecommendedConfig) {
+	ignorePrefixes := []string{
+		"/swaggerapi",
+		fmt.Sprintf("/apis/%s/%s", uiv1alpha1.SchemeGroupVersion, uiv1alpha1.ResourceBackupOverviews),
+	}
+
+	serverConfig.BuildHandlerChainFunc = func(apiHandler http.Handler, c *genericapiserver.Config) http.Handler {
+		return instrumentation.BuildHandlerChain(backups.WithDeletionQuery(apiHandler), c)
+	}
+	serverConfig.EffectiveVersion = basecompatibility.NewEffectiveVersionFromString("v1.0.0", "", "")
+
+	serverConfig.OpenAPIConfig = genericapiserver.DefaultOpenAPIConfig(
+		ou.GetDefinitions(
+			api.GetOpenAPIDefinitionsWithRetentionPolicy,
+			uiv1alpha1.GetOpenAPIDefinitions,
+			uisrv.GetOpenAPIDefinitions,
+		),
+		openapi.NewDefinitionNamer(apiserver.Scheme))
+	serverConfig.OpenAPIConfig.Info.Title = "stash-ui-server"
+	serverConfig.OpenAPIConfig.Info.Version = v.Version.Version
+	serverConfig.OpenAPIConfig.IgnorePrefixes = ignorePrefixes
+
+	serverConfig.OpenAPIV3Config = genericapiserver.DefaultOpenAPIV3Config(
+		ou.GetDefinitions(
+			api.GetOpenAPIDefinitionsWithRetentionPolicy,
+			uiv1alpha1.GetOpenAPIDefinitions,
+			uisrv.GetOpenAPIDefinitions,
+		),
+		openapi.NewDefinitionNamer(apiserver.Scheme))
+	serverConfig.OpenAPIV3Config.Info.Title = "stash-ui-server"
+	serverConfig.OpenAPIV3Config.Info.Version = v.Version.Version
+	serverConfig.OpenAPIV3Config.IgnorePrefixes = ignorePrefixes
+}
@@ -0,0 +1,184 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmds
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	groupByConfiguration = "configuration"
+	groupByNamespace     = "namespace"
+)
+
+var sloColumns = []string{
+	"NAMESPACE", "NAME", "EXPECTED", "CREATED", "SUCCEEDED", "FAILED", "SKIPPED",
+	"SUCCESS RATE", "MTBF", "LONGEST GAP", "LONGEST GAP START",
+}
+
+type sloReportOptions struct {
+	kubeconfigOptions
+
+	Namespace     string
+	AllNamespaces bool
+	Window        time.Duration
+	End           string
+	GroupBy       string
+	Output        string
+}
+
+func NewCmdSLOReport(ctx context.Context, out io.Writer) *cobra.Command {
+	o := &sloReportOptions{
+		Namespace: metav1.NamespaceDefault,
+		Window:    30 * 24 * time.Hour,
+		GroupBy:   groupByConfiguration,
+		Output:    outputTable,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "slo-report",
+		Short:             "Print the backup success rates of a time window",
+		Long:              "Request a BackupSLOReport from the ui server and print it as a table, JSON or CSV",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(ctx, out)
+		},
+	}
+
+	flags := cmd.Flags()
+	o.kubeconfigOptions.AddFlags(flags)
+	flags.StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "The namespace of the BackupConfigurations")
+	flags.BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "Report the BackupConfigurations of all namespaces")
+	flags.DurationVar(&o.Window, "window", o.Window, "Length of the reported time window")
+	flags.StringVar(&o.End, "end", o.End, "End of the reported time window in RFC3339 format. Defaults to now.")
+	flags.StringVar(&o.GroupBy, "group-by", o.GroupBy, "Report per configuration or per namespace")
+	flags.StringVarP(&o.Output, "output", "o", o.Output, "Output format. One of: table, json, csv")
+
+	return cmd
+}
+
+func (o *sloReportOptions) Run(ctx context.Context, out io.Writer) error {
+	switch o.Output {
+	case outputTable, outputJSON, outputCSV:
+	default:
+		return fmt.Errorf("unknown output format %q, must be one of: table, json, csv", o.Output)
+	}
+	if o.GroupBy != groupByConfiguration && o.GroupBy != groupByNamespace {
+		return fmt.Errorf("unknown grouping %q, must be one of: configuration, namespace", o.GroupBy)
+	}
+
+	report := &uisrv.BackupSLOReport{
+		Spec: uisrv.BackupSLOReportSpec{
+			Window: &metav1.Duration{Duration: o.Window},
+		},
+	}
+	if !o.AllNamespaces {
+		report.Spec.Namespace = o.Namespace
+	}
+	if o.End != "" {
+		end, err := time.Parse(time.RFC3339, o.End)
+		if err != nil {
+			return fmt.Errorf("invalid end %q, reason: %v", o.End, err)
+		}
+		report.Spec.End = &metav1.Time{Time: end}
+	}
+
+	kc, err := o.Client()
+	if err != nil {
+		return err
+	}
+	if err := kc.Create(ctx, report); err != nil {
+		return fmt.Errorf("failed to create %s, reason: %v", uisrv.ResourceKindBackupSLOReport, err)
+	}
+	report.APIVersion = uisrv.SchemeGroupVersion.String()
+	report.Kind = uisrv.ResourceKindBackupSLOReport
+
+	return writeSLOReport(out, o.Output, o.GroupBy, report)
+}
+
+func writeSLOReport(w io.Writer, format, groupBy string, report *uisrv.BackupSLOReport) error {
+	if format == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	slos := report.Status.Configurations
+	if groupBy == groupByNamespace {
+		slos = report.Status.Namespaces
+	}
+	if format == outputCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(sloColumns); err != nil {
+			return err
+		}
+		for _, slo := range slos {
+			if err := cw.Write(sloRow(slo)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.Join(sloColumns, "\t"))
+	for _, slo := range slos {
+		_, _ = fmt.Fprintln(tw, strings.Join(sloRow(slo), "\t"))
+	}
+	return tw.Flush()
+}
+
+func sloRow(slo uisrv.BackupSLO) []string {
+	row := []string{
+		slo.Namespace,
+		slo.Name,
+		strconv.FormatInt(slo.ExpectedRuns, 10),
+		strconv.FormatInt(slo.Created, 10),
+		strconv.FormatInt(slo.Succeeded, 10),
+		strconv.FormatInt(slo.Failed, 10),
+		strconv.FormatInt(slo.Skipped, 10),
+		"",
+		formatDuration(slo.MeanTimeBetweenFailures),
+		formatDuration(slo.LongestGap),
+		formatTime(slo.LongestGapStart),
+	}
+	if slo.SuccessRate != nil {
+		row[7] = strconv.FormatFloat(*slo.SuccessRate, 'f', 2, 64)
+	}
+	return row
+}
+
+func formatDuration(d *metav1.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.Duration.String()
+}
@@ -0,0 +1,82 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"stash.appscode.dev/ui-server/pkg/supportbundle"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdSupportBundle(ctx context.Context, out io.Writer) *cobra.Command {
+	var (
+		kubeconfig kubeconfigOptions
+		opts       = supportbundle.Options{Redact: supportbundle.RedactOptions{Annotations: true}}
+		output     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect Stash objects into a tarball for support tickets",
+		Long: `Collect the Stash objects, their Events, CronJobs and Jobs and the computed backup overviews
+into a gzipped tarball with a manifest and checksums. The last applied configuration is always removed.`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kc, err := kubeconfig.Client()
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = "stash-support-bundle-" + time.Now().UTC().Format("20060102T150405Z") + ".tar.gz"
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			m, err := supportbundle.Write(ctx, kc, f, opts)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				_ = os.Remove(output)
+				return err
+			}
+
+			_, _ = fmt.Fprintf(out, "wrote %d files to %s\n", len(m.Files)+2, output)
+			for _, e := range m.Errors {
+				_, _ = fmt.Fprintf(out, "warning: %s\n", e)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	kubeconfig.AddFlags(flags)
+	flags.StringVarP(&opts.Namespace, "namespace", "n", opts.Namespace, "Collect the namespaced objects of this namespace only. All namespaces are collected if empty.")
+	flags.StringVarP(&output, "output", "o", output, "Path of the tarball. Defaults to stash-support-bundle-<timestamp>.tar.gz")
+	flags.BoolVar(&opts.Redact.SecretNames, "redact-secret-names", opts.Redact.SecretNames, "Replace the names of referenced Secrets, also in the messages of Events")
+	flags.BoolVar(&opts.Redact.Annotations, "redact-annotations", opts.Redact.Annotations, "Replace the values of annotations outside of the Kubernetes and Stash domains")
+
+	return cmd
+}
@@ -0,0 +1,340 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// retentionGraceBackups is the number of backups after a change of a
+// BackupConfiguration during which a drop of the snapshot count is expected
+const retentionGraceBackups = 2
+
+// Condition is a problem of a BackupConfiguration detected by the evaluator
+type Condition string
+
+const (
+	ConditionLastSessionFailed  Condition = "LastSessionFailed"
+	ConditionIntegrityLost      Condition = "IntegrityLost"
+	ConditionOverdue            Condition = "Overdue"
+	ConditionPausedTooLong      Condition = "PausedTooLong"
+	ConditionRepositoryNotFound Condition = "RepositoryNotFound"
+	ConditionTargetGone         Condition = "TargetGone"
+	ConditionSnapshotsDropped   Condition = "SnapshotCountDropped"
+)
+
+// Transition is a condition of a BackupConfiguration that started or stopped firing
+type Transition struct {
+	Condition Condition
+	Firing    bool
+	Message   string
+	Time      time.Time
+	// Config is the BackupConfiguration as seen by the evaluation
+	Config *stashv1beta1.BackupConfiguration
+}
+
+// Sink receives the transitions found by an evaluation
+type Sink interface {
+	Notify(ctx context.Context, transitions []Transition)
+}
+
+// Options tunes the evaluator
+type Options struct {
+	// Interval between two evaluations
+	Interval time.Duration
+	// PausedAlertAfter is the time a configuration may stay paused before it is reported
+	PausedAlertAfter time.Duration
+	// APIReader looks up the backup targets. They are not read from the cache,
+	// as they can be of any kind. Targets are not checked if nil.
+	APIReader client.Reader
+}
+
+// state is what the evaluator remembers about a BackupConfiguration
+type state struct {
+	// firing maps the firing conditions to their messages
+	firing map[Condition]string
+	// pausedSince is when the configuration was first seen paused, for
+	// configurations paused without the ui server
+	pausedSince time.Time
+
+	// snapshots, lastBackupTime and generation are the snapshot count and the
+	// last backup of the repository and the generation of the configuration
+	// at the previous evaluation
+	snapshots      int64
+	lastBackupTime *metav1.Time
+	generation     int64
+	// graceBackups counts down the backups after a change of the configuration
+	// during which the snapshot count may drop
+	graceBackups int
+	// snapshotsDropped describes an unexpected drop of the snapshot count. It
+	// is cleared by the next successful backup.
+	snapshotsDropped string
+}
+
+// Evaluator periodically computes the conditions of all BackupConfigurations
+// and passes their transitions on to the sinks. The state is kept in memory,
+// so the conditions that fire at startup are reported again after a restart
+// or a change of the leader.
+type Evaluator struct {
+	kc    client.Client
+	opts  Options
+	sinks []Sink
+
+	states map[client.ObjectKey]*state
+}
+
+var (
+	_ manager.Runnable               = &Evaluator{}
+	_ manager.LeaderElectionRunnable = &Evaluator{}
+)
+
+func New(kc client.Client, opts Options, sinks ...Sink) *Evaluator {
+	return &Evaluator{
+		kc:     kc,
+		opts:   opts,
+		sinks:  sinks,
+		states: map[client.ObjectKey]*state{},
+	}
+}
+
+// NeedLeaderElection is true, so that the replicas of the server do not each
+// notify about the same transitions.
+func (e *Evaluator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start evaluates the BackupConfigurations periodically until the context is done
+func (e *Evaluator) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		transitions, err := e.Evaluate(ctx, time.Now())
+		if err != nil {
+			klog.Errorf("failed to evaluate BackupConfigurations, reason: %v", err)
+			return
+		}
+		if len(transitions) == 0 {
+			return
+		}
+		for _, s := range e.sinks {
+			s.Notify(ctx, transitions)
+		}
+	}, e.opts.Interval)
+	return nil
+}
+
+// Evaluate computes the conditions of all BackupConfigurations and returns the
+// ones that changed since the previous evaluation
+func (e *Evaluator) Evaluate(ctx context.Context, now time.Time) ([]Transition, error) {
+	var cfgs stashv1beta1.BackupConfigurationList
+	if err := e.kc.List(ctx, &cfgs); err != nil {
+		return nil, err
+	}
+	var sessions stashv1beta1.BackupSessionList
+	if err := e.kc.List(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	sessionsByNamespace := map[string][]stashv1beta1.BackupSession{}
+	for _, s := range sessions.Items {
+		sessionsByNamespace[s.Namespace] = append(sessionsByNamespace[s.Namespace], s)
+	}
+
+	var transitions []Transition
+	seen := map[client.ObjectKey]bool{}
+	for i := range cfgs.Items {
+		cfg := &cfgs.Items[i]
+		key := client.ObjectKeyFromObject(cfg)
+		seen[key] = true
+
+		st, ok := e.states[key]
+		if !ok {
+			st = &state{firing: map[Condition]string{}}
+			e.states[key] = st
+		}
+		firing, err := e.conditions(ctx, cfg, sessionsByNamespace[cfg.Namespace], st, now)
+		if err != nil {
+			klog.Errorf("failed to evaluate BackupConfiguration %s, reason: %v", key, err)
+			continue
+		}
+
+		for c, msg := range firing {
+			if _, ok := st.firing[c]; !ok {
+				transitions = append(transitions, Transition{Condition: c, Firing: true, Message: msg, Time: now, Config: cfg})
+			}
+		}
+		for c, msg := range st.firing {
+			if _, ok := firing[c]; !ok {
+				transitions = append(transitions, Transition{Condition: c, Firing: false, Message: msg, Time: now, Config: cfg})
+			}
+		}
+		st.firing = firing
+	}
+	for key := range e.states {
+		if !seen[key] {
+			delete(e.states, key)
+		}
+	}
+
+	sort.SliceStable(transitions, func(i, j int) bool {
+		if transitions[i].Config.Namespace != transitions[j].Config.Namespace {
+			return transitions[i].Config.Namespace < transitions[j].Config.Namespace
+		}
+		if transitions[i].Config.Name != transitions[j].Config.Name {
+			return transitions[i].Config.Name < transitions[j].Config.Name
+		}
+		return transitions[i].Condition < transitions[j].Condition
+	})
+	return transitions, nil
+}
+
+// conditions returns the firing conditions of a BackupConfiguration along with their messages
+func (e *Evaluator) conditions(ctx context.Context, cfg *stashv1beta1.BackupConfiguration, sessions []stashv1beta1.BackupSession, st *state, now time.Time) (map[Condition]string, error) {
+	firing := map[Condition]string{}
+
+	if s := backups.LatestBackupSession(sessions, stashv1beta1.ResourceKindBackupConfiguration, cfg.Name); s != nil &&
+		s.Status.Phase == stashv1beta1.BackupSessionFailed {
+		firing[ConditionLastSessionFailed] = fmt.Sprintf("BackupSession %s failed", s.Name)
+	}
+
+	repoKey := client.ObjectKey{Namespace: cfg.Spec.Repository.Namespace, Name: cfg.Spec.Repository.Name}
+	if repoKey.Namespace == "" {
+		repoKey.Namespace = cfg.Namespace
+	}
+	repo := &stashv1alpha1.Repository{}
+	if err := e.kc.Get(ctx, repoKey, repo); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		firing[ConditionRepositoryNotFound] = fmt.Sprintf("Repository %s not found", repoKey)
+		repo = nil
+	}
+	if repo != nil && repo.Status.Integrity != nil && !*repo.Status.Integrity {
+		firing[ConditionIntegrityLost] = fmt.Sprintf("integrity check of Repository %s failed", repoKey)
+	}
+	if repo != nil {
+		if msg := st.observeSnapshots(cfg, repo); msg != "" {
+			firing[ConditionSnapshotsDropped] = msg
+		}
+	}
+	if msg, err := e.targetGone(ctx, cfg); err != nil {
+		// the target is unknown, keep its condition as it was
+		klog.Warningf("failed to look up the target of BackupConfiguration %s/%s, reason: %v", cfg.Namespace, cfg.Name, err)
+		if msg, ok := st.firing[ConditionTargetGone]; ok {
+			firing[ConditionTargetGone] = msg
+		}
+	} else if msg != "" {
+		firing[ConditionTargetGone] = msg
+	}
+
+	if cfg.Spec.Paused {
+		if st.pausedSince.IsZero() {
+			st.pausedSince = now
+		}
+		pausedAt := st.pausedSince
+		if t, err := time.Parse(time.RFC3339, cfg.Annotations[uisrv.AnnotationPausedAt]); err == nil {
+			pausedAt = t
+		}
+		if e.opts.PausedAlertAfter > 0 && now.Sub(pausedAt) > e.opts.PausedAlertAfter {
+			firing[ConditionPausedTooLong] = fmt.Sprintf("paused since %s", pausedAt.UTC().Format(time.RFC3339))
+		}
+		return firing, nil
+	}
+	st.pausedSince = time.Time{}
+
+	if repo != nil && cfg.Spec.Schedule != "" {
+		sched, err := backups.ParseSchedule(cfg.Spec.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		if backups.IsOverdue(sched, repo.Status.LastBackupTime, cfg.CreationTimestamp.Time, now) {
+			msg := "no backup succeeded yet"
+			if repo.Status.LastBackupTime != nil {
+				msg = fmt.Sprintf("last backup succeeded at %s", repo.Status.LastBackupTime.UTC().Format(time.RFC3339))
+			}
+			firing[ConditionOverdue] = msg
+		}
+	}
+	return firing, nil
+}
+
+// observeSnapshots returns a message while the snapshot count of the
+// repository is below the count seen before. A drop along with a new backup
+// is the retention policy at work. A changed configuration, e.g. with a
+// stricter retention policy, may also drop snapshots until a few backups
+// have run with it.
+func (st *state) observeSnapshots(cfg *stashv1beta1.BackupConfiguration, repo *stashv1alpha1.Repository) string {
+	count := repo.Status.SnapshotCount
+	newBackup := repo.Status.LastBackupTime != nil &&
+		(st.lastBackupTime == nil || st.lastBackupTime.Before(repo.Status.LastBackupTime))
+	if newBackup {
+		st.snapshotsDropped = ""
+	}
+	if st.generation != 0 && st.generation != cfg.Generation {
+		st.graceBackups = retentionGraceBackups
+	} else if newBackup && st.graceBackups > 0 {
+		st.graceBackups--
+	}
+
+	if count < st.snapshots && !newBackup && st.graceBackups == 0 {
+		st.snapshotsDropped = fmt.Sprintf("snapshot count of Repository %s dropped from %d to %d", repo.Name, st.snapshots, count)
+	}
+	st.snapshots = count
+	st.lastBackupTime = repo.Status.LastBackupTime
+	st.generation = cfg.Generation
+	return st.snapshotsDropped
+}
+
+// targetGone returns a message if the target of the configuration does not exist
+func (e *Evaluator) targetGone(ctx context.Context, cfg *stashv1beta1.BackupConfiguration) (string, error) {
+	if e.opts.APIReader == nil || cfg.Spec.Target == nil || cfg.Spec.Target.Ref.Name == "" {
+		return "", nil
+	}
+	ref := cfg.Spec.Target.Ref
+	ns := ref.Namespace
+	if ns == "" {
+		ns = cfg.Namespace
+	}
+
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+	err := e.opts.APIReader.Get(ctx, client.ObjectKey{Namespace: ns, Name: ref.Name}, obj)
+	switch {
+	case err == nil:
+		return "", nil
+	case apierrors.IsNotFound(err):
+		return fmt.Sprintf("%s %s/%s not found", ref.Kind, ns, ref.Name), nil
+	case meta.IsNoMatchError(err):
+		return fmt.Sprintf("%s %s is not served by the cluster", ref.APIVersion, ref.Kind), nil
+	default:
+		return "", err
+	}
+}
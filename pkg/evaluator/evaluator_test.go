@@ -0,0 +1,190 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evaluator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// failingReader fails every lookup of a backup target
+type failingReader struct {
+	client.Reader
+	err error
+}
+
+func (r failingReader) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return r.err
+}
+
+func newConfig() *stashv1beta1.BackupConfiguration {
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: "demo", Generation: 1},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			Repository: kmapi.ObjectReference{Name: "mysql-repo"},
+		},
+	}
+	cfg.Spec.Target = &stashv1beta1.BackupTarget{
+		Ref: stashv1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "mysql"},
+	}
+	return cfg
+}
+
+func newRepository() *stashv1alpha1.Repository {
+	return &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "mysql-repo", Namespace: "demo"}}
+}
+
+// conditionsOf formats transitions as condition=firing
+func conditionsOf(transitions []Transition) map[Condition]bool {
+	out := map[Condition]bool{}
+	for _, tr := range transitions {
+		out[tr.Condition] = tr.Firing
+	}
+	return out
+}
+
+func TestEvaluateTransitions(t *testing.T) {
+	kc := testclient.New(t, newConfig())
+	e := New(kc, Options{})
+	now := time.Now()
+
+	transitions, err := e.Evaluate(context.TODO(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := conditionsOf(transitions); len(got) != 1 || !got[ConditionRepositoryNotFound] {
+		t.Fatalf("first evaluation %v, want RepositoryNotFound firing", got)
+	}
+
+	// a condition that keeps firing is not reported again
+	transitions, err = e.Evaluate(context.TODO(), now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transitions) != 0 {
+		t.Fatalf("second evaluation %v, want none", conditionsOf(transitions))
+	}
+
+	if err := kc.Create(context.TODO(), newRepository()); err != nil {
+		t.Fatal(err)
+	}
+	transitions, err = e.Evaluate(context.TODO(), now.Add(2*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := conditionsOf(transitions); len(got) != 1 || got[ConditionRepositoryNotFound] {
+		t.Fatalf("third evaluation %v, want RepositoryNotFound resolved", got)
+	}
+	if transitions[0].Message != "Repository demo/mysql-repo not found" {
+		t.Errorf("resolved message %q", transitions[0].Message)
+	}
+}
+
+func TestEvaluateTargetLookupFailure(t *testing.T) {
+	cfg := newConfig()
+	cfg.Spec.Paused = true
+	kc := testclient.New(t, cfg, newRepository())
+	// the target is gone
+	e := New(kc, Options{PausedAlertAfter: time.Hour, APIReader: testclient.New(t)})
+	now := time.Now()
+
+	transitions, err := e.Evaluate(context.TODO(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := conditionsOf(transitions); len(got) != 1 || !got[ConditionTargetGone] {
+		t.Fatalf("first evaluation %v, want TargetGone firing", got)
+	}
+
+	// a failing lookup keeps the condition and does not stop the other ones
+	e.opts.APIReader = failingReader{err: errors.New("connection refused")}
+	transitions, err = e.Evaluate(context.TODO(), now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := conditionsOf(transitions); len(got) != 1 || !got[ConditionPausedTooLong] {
+		t.Fatalf("second evaluation %v, want only PausedTooLong firing", got)
+	}
+}
+
+func TestObserveSnapshots(t *testing.T) {
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	type observation struct {
+		generation int64
+		count      int64
+		// backups is the number of backups so far, it sets the last backup time
+		backups int
+		dropped bool
+	}
+	cases := []struct {
+		name         string
+		observations []observation
+	}{
+		{
+			name: "a drop without a backup fires until the next backup",
+			observations: []observation{
+				{generation: 1, count: 5, backups: 5},
+				{generation: 1, count: 3, backups: 5, dropped: true},
+				{generation: 1, count: 3, backups: 5, dropped: true},
+				{generation: 1, count: 4, backups: 6},
+			},
+		},
+		{
+			name: "a drop along with a backup is retention",
+			observations: []observation{
+				{generation: 1, count: 5, backups: 5},
+				{generation: 1, count: 4, backups: 6},
+			},
+		},
+		{
+			name: "drops are expected for a few backups after a change",
+			observations: []observation{
+				{generation: 1, count: 10, backups: 10},
+				{generation: 2, count: 8, backups: 10},
+				{generation: 2, count: 7, backups: 11},
+				{generation: 2, count: 6, backups: 11},
+				{generation: 2, count: 6, backups: 12},
+				{generation: 2, count: 5, backups: 12, dropped: true},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			st := &state{}
+			for i, o := range c.observations {
+				cfg := newConfig()
+				cfg.Generation = o.generation
+				repo := newRepository()
+				repo.Status.SnapshotCount = o.count
+				repo.Status.LastBackupTime = &metav1.Time{Time: base.Add(time.Duration(o.backups) * time.Hour)}
+				if msg := st.observeSnapshots(cfg, repo); (msg != "") != o.dropped {
+					t.Errorf("observation %d: dropped = %q, want %v", i, msg, o.dropped)
+				}
+			}
+		})
+	}
+}
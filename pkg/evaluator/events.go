@@ -0,0 +1,122 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evaluator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+)
+
+// EventConditions are the conditions recorded as Events by default. The
+// Stash operator already reports failed sessions and missing repositories.
+var EventConditions = map[Condition]bool{
+	ConditionOverdue:            true,
+	ConditionIntegrityLost:      true,
+	ConditionPausedTooLong:      true,
+	ConditionTargetGone:         true,
+	ConditionSnapshotsDropped:   true,
+	ConditionLastSessionFailed:  false,
+	ConditionRepositoryNotFound: false,
+}
+
+// EventSink records the transitions of the enabled conditions as Events on
+// the BackupConfiguration. Firing conditions are recorded as warnings, resolved
+// ones as normal Events. Each condition of a BackupConfiguration has its own
+// rate limit, so that a flapping condition can not starve the others. Events
+// beyond it are dropped.
+type EventSink struct {
+	recorder   record.EventRecorder
+	conditions map[Condition]bool
+	qps        float32
+	burst      int
+
+	mu       sync.Mutex
+	limiters map[eventKey]*eventLimiter
+}
+
+type eventKey struct {
+	namespace string
+	name      string
+	condition Condition
+}
+
+type eventLimiter struct {
+	flowcontrol.RateLimiter
+	lastUsed time.Time
+}
+
+var _ Sink = &EventSink{}
+
+func NewEventSink(recorder record.EventRecorder, conditions map[Condition]bool, qps float32, burst int) *EventSink {
+	return &EventSink{
+		recorder:   recorder,
+		conditions: conditions,
+		qps:        qps,
+		burst:      burst,
+		limiters:   map[eventKey]*eventLimiter{},
+	}
+}
+
+func (s *EventSink) Notify(_ context.Context, transitions []Transition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.prune(now)
+	for _, t := range transitions {
+		if !s.conditions[t.Condition] {
+			continue
+		}
+		if !s.limiter(t, now).TryAccept() {
+			klog.V(3).Infof("dropping %s event of BackupConfiguration %s/%s, rate limit exceeded", t.Condition, t.Config.Namespace, t.Config.Name)
+			continue
+		}
+		if t.Firing {
+			s.recorder.Event(t.Config, core.EventTypeWarning, string(t.Condition), t.Message)
+		} else {
+			s.recorder.Event(t.Config, core.EventTypeNormal, string(t.Condition)+"Resolved", "Cleared: "+t.Message)
+		}
+	}
+}
+
+func (s *EventSink) limiter(t Transition, now time.Time) *eventLimiter {
+	key := eventKey{namespace: t.Config.Namespace, name: t.Config.Name, condition: t.Condition}
+	l, ok := s.limiters[key]
+	if !ok {
+		l = &eventLimiter{RateLimiter: flowcontrol.NewTokenBucketRateLimiter(s.qps, s.burst)}
+		s.limiters[key] = l
+	}
+	l.lastUsed = now
+	return l
+}
+
+// prune drops the limiters that have refilled their whole burst, a new
+// limiter behaves the same
+func (s *EventSink) prune(now time.Time) {
+	refill := time.Duration(float64(s.burst) / float64(s.qps) * float64(time.Second))
+	for key, l := range s.limiters {
+		if now.Sub(l.lastUsed) > refill {
+			delete(s.limiters, key)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evaluator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestEventSink(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	sink := NewEventSink(recorder, map[Condition]bool{
+		ConditionOverdue:    true,
+		ConditionTargetGone: false,
+	}, 0.001, 2)
+
+	cfg := &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "mysql"}}
+	now := time.Now()
+	sink.Notify(context.Background(), []Transition{
+		{Condition: ConditionOverdue, Firing: true, Message: "no backup succeeded yet", Time: now, Config: cfg},
+		{Condition: ConditionTargetGone, Firing: true, Message: "StatefulSet demo/mysql not found", Time: now, Config: cfg},
+		{Condition: ConditionOverdue, Firing: false, Message: "no backup succeeded yet", Time: now, Config: cfg},
+		// dropped by the rate limiter
+		{Condition: ConditionOverdue, Firing: true, Message: "no backup succeeded yet", Time: now, Config: cfg},
+	})
+	close(recorder.Events)
+
+	var events []string
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+	expected := []string{
+		"Warning Overdue no backup succeeded yet",
+		"Normal OverdueResolved Cleared: no backup succeeded yet",
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("recorded %q, expected %q", events, expected)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("event %d = %q, expected %q", i, events[i], expected[i])
+		}
+	}
+}
+
+func TestEventSinkLimitsEachCondition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	sink := NewEventSink(recorder, EventConditions, 0.001, 1)
+
+	mysql := &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "mysql"}}
+	redis := &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "redis"}}
+	now := time.Now()
+	sink.Notify(context.Background(), []Transition{
+		{Condition: ConditionOverdue, Firing: true, Message: "no backup succeeded yet", Time: now, Config: mysql},
+		// a flapping condition only exhausts its own limit
+		{Condition: ConditionOverdue, Firing: false, Message: "no backup succeeded yet", Time: now, Config: mysql},
+		{Condition: ConditionIntegrityLost, Firing: true, Message: "integrity check failed", Time: now, Config: mysql},
+		{Condition: ConditionOverdue, Firing: true, Message: "no backup succeeded yet", Time: now, Config: redis},
+	})
+	close(recorder.Events)
+
+	var events []string
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+	expected := []string{
+		"Warning Overdue no backup succeeded yet",
+		"Warning IntegrityLost integrity check failed",
+		"Warning Overdue no backup succeeded yet",
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("recorded %q, expected %q", events, expected)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("event %d = %q, expected %q", i, events[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Recorder periodically writes the overviews of all BackupConfigurations and
+// the outcomes of completed BackupSessions to the store. Overviews are only
+// written if they changed, except for the daily checkpoint.
+type Recorder struct {
+	kc       client.Client
+	store    *Store
+	interval time.Duration
+
+	// day is the segment of the last checkpoint
+	day      string
+	last     map[types.NamespacedName]Overview
+	sessions map[types.UID]bool
+}
+
+var _ manager.Runnable = &Recorder{}
+
+func NewRecorder(kc client.Client, store *Store, interval time.Duration) *Recorder {
+	return &Recorder{
+		kc:       kc,
+		store:    store,
+		interval: interval,
+		sessions: map[types.UID]bool{},
+	}
+}
+
+// Start records the history periodically until the context is done
+func (r *Recorder) Start(ctx context.Context) error {
+	uids, err := r.store.sessionUIDs()
+	if err != nil {
+		return err
+	}
+	for uid := range uids {
+		r.sessions[uid] = true
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		now := time.Now()
+		if err := r.Record(ctx, now); err != nil {
+			klog.Errorf("failed to record backup history, reason: %v", err)
+		}
+		if err := r.store.Prune(now); err != nil {
+			klog.Errorf("failed to prune backup history, reason: %v", err)
+		}
+	}, r.interval)
+	return nil
+}
+
+// Record writes the current overviews and the newly completed sessions
+func (r *Recorder) Record(ctx context.Context, now time.Time) error {
+	var cfgs stashv1beta1.BackupConfigurationList
+	if err := r.kc.List(ctx, &cfgs); err != nil {
+		return err
+	}
+	var sessions stashv1beta1.BackupSessionList
+	if err := r.kc.List(ctx, &sessions); err != nil {
+		return err
+	}
+
+	var entries []Entry
+	current := map[types.NamespacedName]Overview{}
+	overviews := backups.NewOverviewBuilder(r.kc)
+	for i := range cfgs.Items {
+		cfg := &cfgs.Items[i]
+		key := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.Name}
+		bo, err := overviews.Build(ctx, cfg.DeepCopy())
+		if err != nil {
+			klog.Warningf("failed to record history of BackupConfiguration %s, reason: %v", key, err)
+			if prev, ok := r.last[key]; ok {
+				current[key] = prev
+			}
+			continue
+		}
+		o := Overview{
+			Namespace: cfg.Namespace,
+			Name:      cfg.Name,
+			UID:       cfg.UID,
+			Spec:      bo.Spec,
+			Phase:     cfg.Status.Phase,
+		}
+		// the upcoming backup time changes with every record
+		o.Spec.UpcomingBackupTime = nil
+		current[key] = o
+	}
+
+	day := segmentName(now)
+	if day != r.day {
+		entries = append(entries, Entry{Time: now, Checkpoint: true})
+		for _, o := range current {
+			o := o
+			entries = append(entries, Entry{Time: now, Overview: &o})
+		}
+	} else {
+		for key, o := range current {
+			if prev, ok := r.last[key]; ok && equality.Semantic.DeepEqual(prev, o) {
+				continue
+			}
+			o := o
+			entries = append(entries, Entry{Time: now, Overview: &o})
+		}
+		for key, prev := range r.last {
+			if _, ok := current[key]; !ok {
+				entries = append(entries, Entry{Time: now, Overview: &Overview{Namespace: prev.Namespace, Name: prev.Name, UID: prev.UID, Deleted: true}})
+			}
+		}
+	}
+
+	present := map[types.UID]bool{}
+	for _, s := range sessions.Items {
+		present[s.UID] = true
+		if r.sessions[s.UID] {
+			continue
+		}
+		switch s.Status.Phase {
+		case stashv1beta1.BackupSessionSucceeded, stashv1beta1.BackupSessionFailed, stashv1beta1.BackupSessionSkipped:
+		default:
+			continue
+		}
+		session := NewSession(&s)
+		entries = append(entries, Entry{Time: now, Session: &session})
+	}
+
+	if err := r.store.Append(entries...); err != nil {
+		return err
+	}
+	r.day = day
+	r.last = current
+	// pruned sessions won't show up again
+	for uid := range r.sessions {
+		if !present[uid] {
+			delete(r.sessions, uid)
+		}
+	}
+	for _, e := range entries {
+		if e.Session != nil {
+			r.sessions[e.Session.UID] = true
+		}
+	}
+	return nil
+}
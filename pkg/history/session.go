@@ -0,0 +1,84 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	core "k8s.io/api/core/v1"
+)
+
+// Reasons of the Events recorded by the Stash operator on completed BackupSessions
+var eventPhases = map[string]stashv1beta1.BackupSessionPhase{
+	"BackupSessionSucceeded": stashv1beta1.BackupSessionSucceeded,
+	"BackupSessionFailed":    stashv1beta1.BackupSessionFailed,
+	"BackupSessionSkipped":   stashv1beta1.BackupSessionSkipped,
+	"BackupSkipped":          stashv1beta1.BackupSessionSkipped,
+}
+
+// BackupSessions are named <invoker>-<unix timestamp> by the Stash operator.
+// The ones triggered through the trigger subresource have a random suffix.
+var reSessionName = regexp.MustCompile(`^(.+)-(\d{9,})(?:-[a-z0-9]{5})?$`)
+
+// NewSession returns the record of a BackupSession
+func NewSession(s *stashv1beta1.BackupSession) Session {
+	return Session{
+		Namespace:   s.Namespace,
+		Name:        s.Name,
+		UID:         s.UID,
+		InvokerKind: s.Spec.Invoker.Kind,
+		InvokerName: s.Spec.Invoker.Name,
+		Phase:       s.Status.Phase,
+		Created:     s.CreationTimestamp.Time,
+		Duration:    s.Status.SessionDuration,
+	}
+}
+
+// SessionFromEvent returns the BackupSession of an Event recorded by the
+// Stash operator on its completion. The invoker and the creation time are
+// derived from the session name, Events of BackupBatches can not be told
+// apart and are taken as of BackupConfigurations.
+func SessionFromEvent(ev *core.Event) (Session, bool) {
+	phase, ok := eventPhases[ev.Reason]
+	if !ok || ev.InvolvedObject.Kind != stashv1beta1.ResourceKindBackupSession {
+		return Session{}, false
+	}
+	m := reSessionName.FindStringSubmatch(ev.InvolvedObject.Name)
+	if m == nil {
+		return Session{}, false
+	}
+	created := ev.FirstTimestamp.Time
+	if created.IsZero() {
+		created = ev.EventTime.Time
+	}
+	if ts, err := strconv.ParseInt(m[2], 10, 64); err == nil {
+		created = time.Unix(ts, 0)
+	}
+	return Session{
+		Namespace:   ev.InvolvedObject.Namespace,
+		Name:        ev.InvolvedObject.Name,
+		UID:         ev.InvolvedObject.UID,
+		InvokerKind: stashv1beta1.ResourceKindBackupConfiguration,
+		InvokerName: m[1],
+		Phase:       phase,
+		Created:     created,
+	}, true
+}
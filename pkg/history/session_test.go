@@ -0,0 +1,53 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	core "k8s.io/api/core/v1"
+)
+
+func TestSessionFromEvent(t *testing.T) {
+	for name, want := range map[string]string{
+		"mysql-1714521600":       "mysql",
+		"mysql-1714521600-x7k2p": "mysql",
+		"my-db-1714521600":       "my-db",
+		"mysql-latest":           "",
+	} {
+		s, ok := SessionFromEvent(&core.Event{
+			InvolvedObject: core.ObjectReference{Kind: stashv1beta1.ResourceKindBackupSession, Namespace: "demo", Name: name},
+			Reason:         "BackupSessionSucceeded",
+		})
+		if ok != (want != "") || s.InvokerName != want {
+			t.Errorf("invoker of %s = %q, want %q", name, s.InvokerName, want)
+		}
+		if ok && (!s.Created.Equal(time.Unix(1714521600, 0)) || s.Phase != stashv1beta1.BackupSessionSucceeded) {
+			t.Errorf("unexpected session %+v", s)
+		}
+	}
+
+	if _, ok := SessionFromEvent(&core.Event{
+		InvolvedObject: core.ObjectReference{Kind: stashv1beta1.ResourceKindBackupSession, Name: "mysql-1714521600"},
+		Reason:         "BackupSessionRunning",
+	}); ok {
+		t.Error("only Events of completed sessions are taken")
+	}
+}
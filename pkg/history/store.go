@@ -0,0 +1,417 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	segmentLayout = "2006-01-02"
+	segmentSuffix = ".jsonl"
+)
+
+// ErrNoHistory is returned if nothing was recorded before the requested time
+var ErrNoHistory = errors.New("no history recorded before the requested time")
+
+// Overview is the compact overview of a BackupConfiguration
+type Overview struct {
+	Namespace string                          `json:"namespace"`
+	Name      string                          `json:"name"`
+	UID       types.UID                       `json:"uid,omitempty"`
+	Spec      uiapi.BackupOverviewSpec        `json:"spec"`
+	Phase     stashv1beta1.BackupInvokerPhase `json:"phase,omitempty"`
+	// Deleted marks a BackupConfiguration that was deleted since the previous record
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// Session is the outcome of a completed BackupSession
+type Session struct {
+	Namespace   string                          `json:"namespace"`
+	Name        string                          `json:"name"`
+	UID         types.UID                       `json:"uid"`
+	InvokerKind string                          `json:"invokerKind"`
+	InvokerName string                          `json:"invokerName"`
+	Phase       stashv1beta1.BackupSessionPhase `json:"phase"`
+	Created     time.Time                       `json:"created"`
+	Duration    string                          `json:"duration,omitempty"`
+}
+
+// Entry is a line of a segment. A checkpoint is followed by the overviews of
+// all BackupConfigurations, later overviews only record changes.
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Checkpoint bool      `json:"checkpoint,omitempty"`
+	Overview   *Overview `json:"overview,omitempty"`
+	Session    *Session  `json:"session,omitempty"`
+}
+
+// Store keeps the history in a directory with one JSON lines segment per
+// day. Segments older than the retention are deleted as a whole.
+type Store struct {
+	dir       string
+	retention time.Duration
+
+	mu sync.Mutex
+}
+
+func Open(dir string, retention time.Duration) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir, retention: retention}, nil
+}
+
+func segmentName(t time.Time) string {
+	return t.UTC().Format(segmentLayout) + segmentSuffix
+}
+
+// segments returns the days of the stored segments in ascending order
+func (s *Store) segments() ([]time.Time, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var days []time.Time
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), segmentSuffix) {
+			continue
+		}
+		day, err := time.Parse(segmentLayout, strings.TrimSuffix(f.Name(), segmentSuffix))
+		if err != nil {
+			continue
+		}
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days, nil
+}
+
+// Append writes the entries to the segment of their time
+func (s *Store) Append(entries ...Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bySegment := map[string][]Entry{}
+	var names []string
+	for _, e := range entries {
+		name := segmentName(e.Time)
+		if _, ok := bySegment[name]; !ok {
+			names = append(names, name)
+		}
+		bySegment[name] = append(bySegment[name], e)
+	}
+	for _, name := range names {
+		if err := s.appendSegment(name, bySegment[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) appendSegment(name string, entries []Entry) error {
+	f, err := os.OpenFile(filepath.Join(s.dir, name), os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	// terminate a line left partially written by a crash
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			_ = w.WriteByte('\n')
+		}
+	}
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readSegment returns the entries of a segment. Lines that can't be decoded,
+// e.g. the last line of a segment written during a crash, are skipped.
+func (s *Store) readSegment(day time.Time) ([]Entry, error) {
+	name := segmentName(day)
+	f, err := os.Open(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			klog.Warningf("skipping line %d of history segment %s, reason: %v", line, name, err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Prune deletes the segments that only hold entries older than the retention
+func (s *Store) Prune(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days, err := s.segments()
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-s.retention)
+	for _, day := range days {
+		if !day.AddDate(0, 0, 1).Before(cutoff) {
+			break
+		}
+		if err := os.Remove(filepath.Join(s.dir, segmentName(day))); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// OverviewsAsOf returns the overviews of the BackupConfigurations that existed
+// at the given time, replayed from the last checkpoint before it.
+func (s *Store) OverviewsAsOf(asOf time.Time) ([]Overview, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+	// collect the segments back to the one holding the last checkpoint before asOf
+	var entries []Entry
+	found := false
+	for i := len(days) - 1; i >= 0 && !found; i-- {
+		if days[i].After(asOf) {
+			continue
+		}
+		segment, err := s.readSegment(days[i])
+		if err != nil {
+			return nil, err
+		}
+		last := -1
+		for j, e := range segment {
+			if e.Time.After(asOf) {
+				break
+			}
+			if e.Checkpoint {
+				last = j
+			}
+		}
+		if last >= 0 {
+			segment = segment[last:]
+			found = true
+		}
+		entries = append(segment, entries...)
+	}
+	if !found {
+		return nil, ErrNoHistory
+	}
+
+	overviews := map[types.NamespacedName]Overview{}
+	for _, e := range entries {
+		if e.Time.After(asOf) {
+			continue
+		}
+		if e.Checkpoint {
+			overviews = map[types.NamespacedName]Overview{}
+			continue
+		}
+		if e.Overview == nil {
+			continue
+		}
+		key := types.NamespacedName{Namespace: e.Overview.Namespace, Name: e.Overview.Name}
+		if e.Overview.Deleted {
+			delete(overviews, key)
+		} else {
+			overviews[key] = *e.Overview
+		}
+	}
+
+	result := make([]Overview, 0, len(overviews))
+	for _, o := range overviews {
+		result = append(result, o)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// Record is the overview of a BackupConfiguration from a point in time on.
+// Overview is nil if the configuration did not exist.
+type Record struct {
+	Time     time.Time
+	Overview *Overview
+}
+
+// Timeline returns the recorded overviews of a BackupConfiguration in [start,
+// end], starting with the one in effect at start if it is known.
+func (s *Store) Timeline(key types.NamespacedName, start, end time.Time) ([]Record, error) {
+	var records []Record
+	overviews, err := s.OverviewsAsOf(start)
+	if err != nil && !errors.Is(err, ErrNoHistory) {
+		return nil, err
+	}
+	if err == nil {
+		r := Record{Time: start}
+		for i := range overviews {
+			if overviews[i].Namespace == key.Namespace && overviews[i].Name == key.Name {
+				r.Overview = &overviews[i]
+			}
+		}
+		records = append(records, r)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		if day.AddDate(0, 0, 1).Before(start) || day.After(end) {
+			continue
+		}
+		entries, err := s.readSegment(day)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.Overview == nil || !e.Time.After(start) || e.Time.After(end) ||
+				e.Overview.Namespace != key.Namespace || e.Overview.Name != key.Name {
+				continue
+			}
+			r := Record{Time: e.Time}
+			if !e.Overview.Deleted {
+				r.Overview = e.Overview
+			}
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// Sessions returns the recorded sessions created in [start, end). Sessions
+// are recorded once they complete, so segments after end are read as well.
+func (s *Store) Sessions(start, end time.Time) ([]Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+	var sessions []Session
+	for _, day := range days {
+		if day.AddDate(0, 0, 1).Before(start) {
+			continue
+		}
+		entries, err := s.readSegment(day)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.Session == nil || e.Session.Created.Before(start) || !e.Session.Created.Before(end) {
+				continue
+			}
+			sessions = append(sessions, *e.Session)
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].Created.Before(sessions[j].Created) })
+	return sessions, nil
+}
+
+// Oldest returns the time of the first entry in the store
+func (s *Store) Oldest() (*time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		entries, err := s.readSegment(day)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			return &entries[0].Time, nil
+		}
+	}
+	return nil, nil
+}
+
+// sessionUIDs returns the UIDs of all recorded sessions
+func (s *Store) sessionUIDs() (map[types.UID]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+	uids := map[types.UID]bool{}
+	for _, day := range days {
+		entries, err := s.readSegment(day)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.Session != nil {
+				uids[e.Session.UID] = true
+			}
+		}
+	}
+	return uids, nil
+}
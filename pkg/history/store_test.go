@@ -0,0 +1,129 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(dir, 48*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day1 := time.Date(2024, 5, 14, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	mysql := func(snapshots int64) *Overview {
+		return &Overview{Namespace: "demo", Name: "mysql", Spec: uiapi.BackupOverviewSpec{NumberOfSnapshots: snapshots}}
+	}
+	if err := store.Append(
+		Entry{Time: day1, Checkpoint: true},
+		Entry{Time: day1, Overview: mysql(1)},
+		Entry{Time: day1, Overview: &Overview{Namespace: "demo", Name: "pg"}},
+		Entry{Time: day1.Add(time.Hour), Overview: mysql(2)},
+		Entry{Time: day1.Add(time.Hour), Session: &Session{Namespace: "demo", Name: "mysql-1", UID: "1", Phase: stashv1beta1.BackupSessionSucceeded, Created: day1.Add(50 * time.Minute)}},
+		Entry{Time: day1.Add(2 * time.Hour), Overview: &Overview{Namespace: "demo", Name: "pg", Deleted: true}},
+		Entry{Time: day2, Checkpoint: true},
+		Entry{Time: day2, Overview: mysql(3)},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		asOf     time.Time
+		expected []int64
+		err      error
+	}{
+		{day1.Add(-time.Minute), nil, ErrNoHistory},
+		{day1, []int64{1, 0}, nil},
+		{day1.Add(90 * time.Minute), []int64{2, 0}, nil},
+		{day2.Add(-time.Minute), []int64{2}, nil},
+		{day2.Add(time.Minute), []int64{3}, nil},
+	}
+	for _, c := range cases {
+		overviews, err := store.OverviewsAsOf(c.asOf)
+		if err != c.err {
+			t.Errorf("OverviewsAsOf(%v) returned error %v, expected %v", c.asOf, err, c.err)
+			continue
+		}
+		var snapshots []int64
+		for _, o := range overviews {
+			snapshots = append(snapshots, o.Spec.NumberOfSnapshots)
+		}
+		if len(snapshots) != len(c.expected) {
+			t.Errorf("OverviewsAsOf(%v) = %v, expected %v", c.asOf, snapshots, c.expected)
+			continue
+		}
+		for i := range snapshots {
+			if snapshots[i] != c.expected[i] {
+				t.Errorf("OverviewsAsOf(%v) = %v, expected %v", c.asOf, snapshots, c.expected)
+				break
+			}
+		}
+	}
+
+	timeline, err := store.Timeline(types.NamespacedName{Namespace: "demo", Name: "pg"}, day1.Add(time.Minute), day2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeline) != 2 || timeline[0].Overview == nil || timeline[1].Overview != nil || !timeline[1].Time.Equal(day1.Add(2*time.Hour)) {
+		t.Errorf("Timeline() = %+v, expected pg to exist until it was deleted", timeline)
+	}
+
+	sessions, err := store.Sessions(day1, day2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].Name != "mysql-1" {
+		t.Errorf("Sessions() = %+v, expected mysql-1", sessions)
+	}
+
+	// a partially written line is skipped
+	f, err := os.OpenFile(filepath.Join(dir, segmentName(day2)), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"time":"2024-05-15T1`)
+	_ = f.Close()
+	if err := store.Append(Entry{Time: day2.Add(time.Hour), Overview: mysql(4)}); err != nil {
+		t.Fatal(err)
+	}
+	if overviews, err := store.OverviewsAsOf(day2.Add(time.Hour)); err != nil || len(overviews) != 1 || overviews[0].Spec.NumberOfSnapshots != 4 {
+		t.Errorf("OverviewsAsOf() = %+v, %v after a partial line, expected 4 snapshots", overviews, err)
+	}
+
+	if err := store.Prune(day2.Add(48 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	oldest, err := store.Oldest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldest == nil || !oldest.Equal(day2) {
+		t.Errorf("Oldest() = %v after pruning, expected %v", oldest, day2)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instrumentation
+
+import (
+	"context"
+
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+type instrumentedAuthorizer struct {
+	authorizer.Authorizer
+}
+
+// NewAuthorizer counts the decisions of an authorizer
+func NewAuthorizer(a authorizer.Authorizer) authorizer.Authorizer {
+	return &instrumentedAuthorizer{Authorizer: a}
+}
+
+func (a *instrumentedAuthorizer) Authorize(ctx context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+	decision, reason, err := a.Authorizer.Authorize(ctx, attrs)
+
+	result := "error"
+	if err == nil {
+		switch decision {
+		case authorizer.DecisionAllow:
+			result = "allow"
+		case authorizer.DecisionDeny:
+			result = "deny"
+		default:
+			result = "no_opinion"
+		}
+	}
+	resource := attrs.GetResource()
+	if attrs.GetAPIGroup() != "" {
+		resource += "." + attrs.GetAPIGroup()
+	}
+	authorizationDecisions.WithLabelValues(resource, attrs.GetVerb(), result).Inc()
+	return decision, reason, err
+}
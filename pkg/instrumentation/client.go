@@ -0,0 +1,107 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instrumentation
+
+import (
+	"context"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Readers a read is routed to. A read routed to the cache is not a cache
+// hit, it starts an informer for a kind that is not cached yet.
+const (
+	readerCache = "cache"
+	readerAPI   = "api"
+)
+
+// Results of a read. The hit ratio of a reader is the share of its reads
+// that found the object, a list finds objects if it is not empty.
+const (
+	resultFound    = "found"
+	resultNotFound = "not_found"
+	resultError    = "error"
+)
+
+type instrumentedClient struct {
+	client.Client
+	cached   bool
+	uncached map[schema.GroupKind]bool
+}
+
+// NewClient counts the reads of a client created with the given cache
+// options by whether they are routed to the cache.
+func NewClient(c client.Client, cache *client.CacheOptions) client.Client {
+	ic := &instrumentedClient{
+		Client:   c,
+		cached:   cache != nil && cache.Reader != nil,
+		uncached: map[schema.GroupKind]bool{},
+	}
+	if cache != nil {
+		for _, obj := range cache.DisableFor {
+			if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+				ic.uncached[gvk.GroupKind()] = true
+			}
+		}
+	}
+	return ic
+}
+
+func (c *instrumentedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	result := resultFound
+	switch {
+	case apierrors.IsNotFound(err):
+		result = resultNotFound
+	case err != nil:
+		result = resultError
+	}
+	c.count(obj, result)
+	return err
+}
+
+func (c *instrumentedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	err := c.Client.List(ctx, list, opts...)
+	result := resultFound
+	switch {
+	case err != nil:
+		result = resultError
+	case meta.LenList(list) == 0:
+		result = resultNotFound
+	}
+	c.count(list, result)
+	return err
+}
+
+func (c *instrumentedClient) count(obj runtime.Object, result string) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return
+	}
+	gk := schema.GroupKind{Group: gvk.Group, Kind: strings.TrimSuffix(gvk.Kind, "List")}
+	reader := readerAPI
+	if c.cached && !c.uncached[gk] {
+		reader = readerCache
+	}
+	clientReads.WithLabelValues(gk.Kind, reader, result).Inc()
+}
@@ -0,0 +1,127 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis/ui"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClientReads(t *testing.T) {
+	kc := fake.NewClientBuilder().WithObjects(&core.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "demo"}}).Build()
+	ic := NewClient(kc, &client.CacheOptions{Reader: kc, DisableFor: []client.Object{&core.Secret{}}})
+
+	reads := func(kind, reader, result string) float64 {
+		return testutil.ToFloat64(clientReads.WithLabelValues(kind, reader, result))
+	}
+	found := reads("ConfigMap", readerCache, resultFound)
+	notFound := reads("ConfigMap", readerCache, resultNotFound)
+	direct := reads("Secret", readerAPI, resultNotFound)
+
+	_ = ic.Get(context.TODO(), client.ObjectKey{Name: "a", Namespace: "demo"}, &core.ConfigMap{})
+	_ = ic.Get(context.TODO(), client.ObjectKey{Name: "b", Namespace: "demo"}, &core.ConfigMap{})
+	_ = ic.List(context.TODO(), &core.ConfigMapList{})
+	_ = ic.List(context.TODO(), &core.SecretList{})
+
+	if got := reads("ConfigMap", readerCache, resultFound) - found; got != 2 {
+		t.Errorf("ConfigMap reads from the cache that found objects = %v, want 2", got)
+	}
+	if got := reads("ConfigMap", readerCache, resultNotFound) - notFound; got != 1 {
+		t.Errorf("ConfigMap reads from the cache that found nothing = %v, want 1", got)
+	}
+	if got := reads("Secret", readerAPI, resultNotFound) - direct; got != 1 {
+		t.Errorf("empty Secret lists from the API server = %v, want 1", got)
+	}
+}
+
+func TestWithMetrics(t *testing.T) {
+	h := withMetrics(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	serve := func(group string) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req = req.WithContext(apirequest.WithRequestInfo(req.Context(), &apirequest.RequestInfo{
+			IsResourceRequest: true,
+			Verb:              "create",
+			APIGroup:          group,
+			Resource:          "backupoverviews",
+			Subresource:       "trigger",
+		}))
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	errs := requestErrors.WithLabelValues("backupoverviews/trigger", "create", "404")
+	before := testutil.ToFloat64(errs)
+	serve(ui.GroupName)
+	serve("apps")
+	if got := testutil.ToFloat64(errs) - before; got != 1 {
+		t.Errorf("request errors = %v, want 1", got)
+	}
+}
+
+func TestAuthorizationDecisions(t *testing.T) {
+	cases := []struct {
+		decision authorizer.Decision
+		err      error
+		want     string
+	}{
+		{decision: authorizer.DecisionAllow, want: "allow"},
+		{decision: authorizer.DecisionDeny, want: "deny"},
+		{decision: authorizer.DecisionNoOpinion, want: "no_opinion"},
+		{decision: authorizer.DecisionDeny, err: errors.New("webhook timeout"), want: "error"},
+	}
+	for _, c := range cases {
+		a := NewAuthorizer(authorizer.AuthorizerFunc(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+			return c.decision, "", c.err
+		}))
+		decisions := authorizationDecisions.WithLabelValues("repositories.stash.appscode.com", "get", c.want)
+		before := testutil.ToFloat64(decisions)
+		_, _, _ = a.Authorize(context.TODO(), authorizer.AttributesRecord{
+			User:            &user.DefaultInfo{Name: "alice"},
+			Verb:            "get",
+			APIGroup:        "stash.appscode.com",
+			Resource:        "repositories",
+			ResourceRequest: true,
+		})
+		if got := testutil.ToFloat64(decisions) - before; got != 1 {
+			t.Errorf("%s decisions = %v, want 1", c.want, got)
+		}
+	}
+}
+
+func TestObserveOverviewPhase(t *testing.T) {
+	before := testutil.CollectAndCount(overviewPhaseDuration)
+	ObserveOverviewPhase("test", time.Now())
+	if got := testutil.CollectAndCount(overviewPhaseDuration) - before; got != 1 {
+		t.Errorf("new phase series = %v, want 1", got)
+	}
+}
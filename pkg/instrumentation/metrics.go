@@ -0,0 +1,81 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package instrumentation measures the ui server itself. The metrics are
+// served along with the backup metrics on the metrics endpoint.
+package instrumentation
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "stash_ui"
+
+// Phases of computing a BackupOverview
+const (
+	OverviewPhaseRepository = "repository"
+	OverviewPhaseSessions   = "sessions"
+	OverviewPhaseCron       = "cron"
+	OverviewPhaseCronJob    = "cronjob"
+)
+
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests to ui resources by resource, verb and response code",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"resource", "verb", "code"})
+	requestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "request_errors_total",
+		Help:      "Number of failed requests to ui resources by resource, verb and response code",
+	}, []string{"resource", "verb", "code"})
+	overviewPhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "overview_phase_duration_seconds",
+		Help:      "Time spent computing a BackupOverview by phase",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 12),
+	}, []string{"phase"})
+	clientReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "client_reads_total",
+		Help:      "Number of reads by kind, the reader they were routed to, the informer cache or the API server, and whether they found objects",
+	}, []string{"kind", "reader", "result"})
+	authorizationDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "authorization_decisions_total",
+		Help:      "Number of authorization decisions by resource, verb and decision",
+	}, []string{"resource", "verb", "decision"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		requestDuration,
+		requestErrors,
+		overviewPhaseDuration,
+		clientReads,
+		authorizationDecisions,
+	)
+}
+
+// ObserveOverviewPhase records the time spent in a phase of computing a BackupOverview
+func ObserveOverviewPhase(phase string, start time.Time) {
+	overviewPhaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
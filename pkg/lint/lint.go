@@ -0,0 +1,248 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+// Level is the severity of a finding, named as in SARIF
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelNote    Level = "note"
+)
+
+const (
+	RuleInvalidManifest       = "invalid-manifest"
+	RuleInvalidSchedule       = "invalid-schedule"
+	RuleRepositoryNotFound    = "repository-not-found"
+	RuleRetentionNoKeepRules  = "retention-no-keep-rules"
+	RuleRetentionPruneOff     = "retention-prune-disabled"
+	RuleOverlappingTargets    = "overlapping-targets"
+	RuleUsagePolicyViolation  = "usage-policy-violation"
+	RuleUsagePolicyUnverified = "usage-policy-unverified"
+)
+
+// Rule describes a check
+type Rule struct {
+	ID          string
+	Level       Level
+	Description string
+}
+
+// Rules lists all checks in the order they are run
+var Rules = []Rule{
+	{RuleInvalidManifest, LevelError, "Stash manifests must decode with the Stash API types."},
+	{RuleInvalidSchedule, LevelError, "Backup schedules must be valid cron expressions."},
+	{RuleRepositoryNotFound, LevelError, "Referenced Repositories must be defined in the linted manifests."},
+	{RuleRetentionNoKeepRules, LevelWarning, "Retention policies without keep rules never forget old snapshots."},
+	{RuleRetentionPruneOff, LevelWarning, "Retention policies with prune disabled do not free the storage of forgotten snapshots."},
+	{RuleOverlappingTargets, LevelError, "A target must be backed up by a single invoker."},
+	{RuleUsagePolicyViolation, LevelError, "Repositories must allow the namespace of the invokers that use them."},
+	{RuleUsagePolicyUnverified, LevelNote, "Namespace selectors of usage policies can only be verified if the Namespace is part of the linted manifests."},
+}
+
+// Finding is a problem found in a manifest
+type Finding struct {
+	Rule      string `json:"rule"`
+	Level     Level  `json:"level"`
+	Message   string `json:"message"`
+	File      string `json:"file"`
+	Line      int    `json:"line,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// Options tunes the checks
+type Options struct {
+	// DefaultNamespace is assumed for manifests without a namespace
+	DefaultNamespace string
+}
+
+// Lint loads the manifests below dir and checks them
+func Lint(dir string, opts Options) ([]Finding, error) {
+	objects, findings, err := load(dir)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, check(objects, opts)...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings, nil
+}
+
+type checker struct {
+	opts     Options
+	findings []Finding
+
+	repositories map[string]object
+	namespaces   map[string]*core.Namespace
+	// targets maps a target to the invoker that backs it up first
+	targets map[string]string
+}
+
+// check runs all checks against the loaded manifests
+func check(objects []object, opts Options) []Finding {
+	if opts.DefaultNamespace == "" {
+		opts.DefaultNamespace = core.NamespaceDefault
+	}
+	c := &checker{
+		opts:         opts,
+		repositories: map[string]object{},
+		namespaces:   map[string]*core.Namespace{},
+		targets:      map[string]string{},
+	}
+	for _, o := range objects {
+		switch obj := o.Object.(type) {
+		case *stashv1alpha1.Repository:
+			c.repositories[c.namespace(obj.Namespace)+"/"+obj.Name] = o
+		case *core.Namespace:
+			c.namespaces[obj.Name] = obj
+		}
+	}
+
+	for _, o := range objects {
+		switch obj := o.Object.(type) {
+		case *stashv1beta1.BackupConfiguration:
+			ns := c.namespace(obj.Namespace)
+			c.checkSchedule(o, obj.Spec.Schedule)
+			c.checkRetention(o, obj.Spec.RetentionPolicy)
+			c.checkRepository(o, ns, obj.Spec.Repository)
+			c.checkTarget(o, ns, obj.Spec.Target)
+		case *stashv1beta1.BackupBatch:
+			ns := c.namespace(obj.Namespace)
+			c.checkSchedule(o, obj.Spec.Schedule)
+			c.checkRetention(o, obj.Spec.RetentionPolicy)
+			c.checkRepository(o, ns, obj.Spec.Repository)
+			for i := range obj.Spec.Members {
+				c.checkTarget(o, ns, obj.Spec.Members[i].Target)
+			}
+		case *stashv1beta1.BackupBlueprint:
+			c.checkSchedule(o, obj.Spec.Schedule)
+			c.checkRetention(o, obj.Spec.RetentionPolicy)
+		}
+	}
+	return c.findings
+}
+
+func (c *checker) namespace(ns string) string {
+	if ns == "" {
+		return c.opts.DefaultNamespace
+	}
+	return ns
+}
+
+func (c *checker) report(o object, rule string, level Level, format string, args ...any) {
+	c.findings = append(c.findings, Finding{
+		Rule:      rule,
+		Level:     level,
+		Message:   fmt.Sprintf(format, args...),
+		File:      o.File,
+		Line:      o.Line,
+		Kind:      o.GetObjectKind().GroupVersionKind().Kind,
+		Namespace: o.GetNamespace(),
+		Name:      o.GetName(),
+	})
+}
+
+func (c *checker) checkSchedule(o object, schedule string) {
+	if schedule == "" {
+		return
+	}
+	if _, err := backups.ParseSchedule(schedule); err != nil {
+		c.report(o, RuleInvalidSchedule, LevelError, "invalid schedule %q: %v", schedule, err)
+	}
+}
+
+func (c *checker) checkRetention(o object, p stashv1alpha1.RetentionPolicy) {
+	if p.KeepLast == 0 && p.KeepHourly == 0 && p.KeepDaily == 0 && p.KeepWeekly == 0 &&
+		p.KeepMonthly == 0 && p.KeepYearly == 0 && len(p.KeepTags) == 0 {
+		c.report(o, RuleRetentionNoKeepRules, LevelWarning, "retention policy %q has no keep rules", p.Name)
+		return
+	}
+	if !p.Prune {
+		c.report(o, RuleRetentionPruneOff, LevelWarning, "retention policy %q does not prune the repository", p.Name)
+	}
+}
+
+func (c *checker) checkRepository(o object, ns string, ref kmapi.ObjectReference) {
+	if ref.Name == "" {
+		return
+	}
+	repoNamespace := ns
+	if ref.Namespace != "" {
+		repoNamespace = ref.Namespace
+	}
+	ro, ok := c.repositories[repoNamespace+"/"+ref.Name]
+	if !ok {
+		c.report(o, RuleRepositoryNotFound, LevelError, "Repository %s/%s is not defined", repoNamespace, ref.Name)
+		return
+	}
+
+	repo := ro.Object.(*stashv1alpha1.Repository).DeepCopy()
+	repo.Namespace = repoNamespace
+	if p := repo.Spec.UsagePolicy; p != nil && p.AllowedNamespaces.From != nil &&
+		*p.AllowedNamespaces.From == stashv1alpha1.NamespacesFromSelector {
+		nsObj, ok := c.namespaces[ns]
+		if !ok {
+			c.report(o, RuleUsagePolicyUnverified, LevelNote, "Repository %s/%s selects the allowed namespaces by labels, but Namespace %s is not defined", repoNamespace, ref.Name, ns)
+			return
+		}
+		if !repo.UsageAllowed(nsObj) {
+			c.report(o, RuleUsagePolicyViolation, LevelError, "Repository %s/%s does not allow namespace %s", repoNamespace, ref.Name, ns)
+		}
+		return
+	}
+	if !repo.UsageAllowed(&core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}) {
+		c.report(o, RuleUsagePolicyViolation, LevelError, "Repository %s/%s does not allow namespace %s", repoNamespace, ref.Name, ns)
+	}
+}
+
+func (c *checker) checkTarget(o object, ns string, target *stashv1beta1.BackupTarget) {
+	if target == nil || target.Ref.Name == "" {
+		return
+	}
+	targetNamespace := ns
+	if target.Ref.Namespace != "" {
+		targetNamespace = target.Ref.Namespace
+	}
+	key := strings.Join([]string{target.Ref.Kind, targetNamespace, target.Ref.Name, target.Alias}, "/")
+	invoker := fmt.Sprintf("%s %s/%s", o.GetObjectKind().GroupVersionKind().Kind, ns, o.GetName())
+	if first, ok := c.targets[key]; ok {
+		c.report(o, RuleOverlappingTargets, LevelError, "%s %s/%s is also backed up by %s", target.Ref.Kind, targetNamespace, target.Ref.Name, first)
+		return
+	}
+	c.targets[key] = invoker
+}
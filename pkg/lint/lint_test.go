@@ -0,0 +1,155 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const repositories = `apiVersion: stash.appscode.com/v1alpha1
+kind: Repository
+metadata:
+  name: gcs-repo
+  namespace: demo
+spec:
+  backend:
+    gcs:
+      bucket: stash-backup
+      prefix: demo
+    storageSecretName: gcs-secret
+---
+apiVersion: stash.appscode.com/v1alpha1
+kind: Repository
+metadata:
+  name: shared-repo
+  namespace: backup
+spec:
+  backend:
+    gcs:
+      bucket: stash-backup
+      prefix: shared
+    storageSecretName: gcs-secret
+  usagePolicy:
+    allowedNamespaces:
+      from: Same
+`
+
+const configurations = `apiVersion: stash.appscode.com/v1beta1
+kind: BackupConfiguration
+metadata:
+  name: mysql
+  namespace: demo
+spec:
+  schedule: "*/5 * * * *"
+  repository:
+    name: gcs-repo
+  target:
+    ref:
+      apiVersion: apps/v1
+      kind: StatefulSet
+      name: mysql
+  retentionPolicy:
+    name: keep-last-5
+    keepLast: 5
+    prune: true
+---
+# the same StatefulSet again, with a typo in the schedule
+apiVersion: stash.appscode.com/v1beta1
+kind: BackupConfiguration
+metadata:
+  name: mysql-copy
+  namespace: demo
+spec:
+  schedule: "*/5 * * *"
+  repository:
+    name: missing-repo
+  target:
+    ref:
+      apiVersion: apps/v1
+      kind: StatefulSet
+      name: mysql
+  retentionPolicy:
+    name: empty
+---
+apiVersion: stash.appscode.com/v1beta1
+kind: BackupConfiguration
+metadata:
+  name: postgres
+  namespace: demo
+spec:
+  schedule: "0 1 * * *"
+  repository:
+    name: shared-repo
+    namespace: backup
+  target:
+    ref:
+      apiVersion: apps/v1
+      kind: StatefulSet
+      name: postgres
+  retentionPolicy:
+    name: no-prune
+    keepDaily: 7
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+`
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "repositories.yaml"), []byte(repositories), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "apps"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "apps", "backups.yml"), []byte(configurations), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := Lint(dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.File+":"+f.Name+":"+f.Rule)
+	}
+	sort.Strings(got)
+	expected := []string{
+		"apps/backups.yml:mysql-copy:invalid-schedule",
+		"apps/backups.yml:mysql-copy:overlapping-targets",
+		"apps/backups.yml:mysql-copy:repository-not-found",
+		"apps/backups.yml:mysql-copy:retention-no-keep-rules",
+		"apps/backups.yml:postgres:retention-prune-disabled",
+		"apps/backups.yml:postgres:usage-policy-violation",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("findings:\n%s\nexpected:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	for _, f := range findings {
+		if f.Name == "mysql-copy" && f.Line != 20 {
+			t.Errorf("finding %s of mysql-copy is reported at line %d, expected 20", f.Rule, f.Line)
+		}
+	}
+}
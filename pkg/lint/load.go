@@ -0,0 +1,145 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"stash.appscode.dev/apimachinery/apis/stash"
+	"stash.appscode.dev/ui-server/pkg/apiserver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// object is a manifest along with the place it was loaded from
+type object struct {
+	client.Object
+	File string
+	Line int
+}
+
+// document is a single YAML document of a manifest file
+type document struct {
+	data []byte
+	line int
+}
+
+// load decodes the manifests of all YAML and JSON files below dir with
+// apiserver.Scheme. Documents of kinds unknown to the scheme are ignored,
+// Stash documents that fail to decode are reported as findings.
+func load(dir string) ([]object, []Finding, error) {
+	var objects []object
+	var findings []Finding
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, doc := range splitDocuments(data) {
+			var tm metav1.TypeMeta
+			if err := yaml.Unmarshal(doc.data, &tm); err != nil || tm.Kind == "" {
+				continue
+			}
+			gv, err := schema.ParseGroupVersion(tm.APIVersion)
+			if err != nil {
+				continue
+			}
+			obj, _, err := apiserver.Codecs.UniversalDeserializer().Decode(doc.data, nil, nil)
+			if err != nil {
+				if strings.HasSuffix(gv.Group, stash.GroupName) {
+					findings = append(findings, Finding{
+						Rule:    RuleInvalidManifest,
+						Level:   LevelError,
+						Message: fmt.Sprintf("failed to decode %s: %v", tm.Kind, err),
+						File:    rel,
+						Line:    doc.line,
+					})
+				}
+				continue
+			}
+			if o, ok := obj.(client.Object); ok {
+				objects = append(objects, object{Object: o, File: rel, Line: doc.line})
+			}
+		}
+		return nil
+	})
+	return objects, findings, err
+}
+
+// splitDocuments splits a YAML stream at the document separators and
+// remembers the line each document starts at
+func splitDocuments(data []byte) []document {
+	var docs []document
+	var buf bytes.Buffer
+	start, n := 1, 0
+	flush := func() {
+		if len(bytes.TrimSpace(buf.Bytes())) > 0 {
+			docs = append(docs, document{data: append([]byte(nil), buf.Bytes()...), line: start})
+		}
+		buf.Reset()
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(make([]byte, 64*1024), len(data)+1)
+	for s.Scan() {
+		n++
+		line := s.Text()
+		if strings.HasPrefix(line, "---") && strings.TrimSpace(strings.TrimPrefix(line, "---")) == "" {
+			flush()
+			start = n + 1
+			continue
+		}
+		if buf.Len() == 0 && strings.TrimSpace(line) == "" {
+			start = n + 1
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	flush()
+	return docs
+}
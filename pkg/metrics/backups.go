@@ -0,0 +1,173 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const collectTimeout = 30 * time.Second
+
+var (
+	backupLabels = []string{"namespace", "name", "repository", "target_kind"}
+
+	lastSuccessDesc = prometheus.NewDesc(
+		"stash_backup_last_success_timestamp_seconds",
+		"Time of the last successful backup of a BackupConfiguration",
+		backupLabels, nil)
+	nextScheduleDesc = prometheus.NewDesc(
+		"stash_backup_next_schedule_timestamp_seconds",
+		"Time of the next scheduled backup of a BackupConfiguration",
+		backupLabels, nil)
+	repositorySizeDesc = prometheus.NewDesc(
+		"stash_backup_repository_size_bytes",
+		"Size of the Repository of a BackupConfiguration",
+		backupLabels, nil)
+	snapshotCountDesc = prometheus.NewDesc(
+		"stash_backup_snapshot_count",
+		"Number of snapshots in the Repository of a BackupConfiguration",
+		backupLabels, nil)
+	integrityDesc = prometheus.NewDesc(
+		"stash_backup_integrity",
+		"Whether the integrity check of the Repository of a BackupConfiguration passed",
+		backupLabels, nil)
+	pausedDesc = prometheus.NewDesc(
+		"stash_backup_paused",
+		"Whether a BackupConfiguration is paused",
+		backupLabels, nil)
+	lastSessionPhaseDesc = prometheus.NewDesc(
+		"stash_backup_last_session_phase",
+		"Phase of the latest BackupSession of a BackupConfiguration",
+		append(backupLabels, "phase"), nil)
+	overdueDesc = prometheus.NewDesc(
+		"stash_backup_overdue",
+		"Whether a BackupConfiguration missed its schedule",
+		backupLabels, nil)
+
+	sessionPhases = []stashv1beta1.BackupSessionPhase{
+		stashv1beta1.BackupSessionPending,
+		stashv1beta1.BackupSessionSkipped,
+		stashv1beta1.BackupSessionRunning,
+		stashv1beta1.BackupSessionSucceeded,
+		stashv1beta1.BackupSessionFailed,
+		stashv1beta1.BackupSessionUnknown,
+	}
+)
+
+// BackupCollector exports the state of every BackupConfiguration as shown
+// in its BackupOverview. It is evaluated on every scrape.
+type BackupCollector struct {
+	kc client.Client
+}
+
+var _ prometheus.Collector = &BackupCollector{}
+
+func NewBackupCollector(kc client.Client) *BackupCollector {
+	return &BackupCollector{kc: kc}
+}
+
+func (c *BackupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lastSuccessDesc
+	ch <- nextScheduleDesc
+	ch <- repositorySizeDesc
+	ch <- snapshotCountDesc
+	ch <- integrityDesc
+	ch <- pausedDesc
+	ch <- lastSessionPhaseDesc
+	ch <- overdueDesc
+}
+
+func (c *BackupCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	cfgList := stashv1beta1.BackupConfigurationList{}
+	if err := c.kc.List(ctx, &cfgList); err != nil {
+		klog.Errorf("failed to list BackupConfigurations, reason: %v", err)
+		return
+	}
+	sessionList := stashv1beta1.BackupSessionList{}
+	if err := c.kc.List(ctx, &sessionList); err != nil {
+		klog.Errorf("failed to list BackupSessions, reason: %v", err)
+		return
+	}
+	sessions := map[string][]stashv1beta1.BackupSession{}
+	for _, s := range sessionList.Items {
+		sessions[s.Namespace] = append(sessions[s.Namespace], s)
+	}
+
+	now := time.Now()
+	overviews := backups.NewOverviewBuilder(c.kc)
+	for i := range cfgList.Items {
+		cfg := &cfgList.Items[i]
+		var targetKind string
+		if cfg.Spec.Target != nil {
+			targetKind = cfg.Spec.Target.Ref.Kind
+		}
+		labels := []string{cfg.Namespace, cfg.Name, cfg.Spec.Repository.Name, targetKind}
+
+		ch <- prometheus.MustNewConstMetric(pausedDesc, prometheus.GaugeValue, boolValue(cfg.Spec.Paused), labels...)
+		if s := backups.LatestBackupSession(sessions[cfg.Namespace], stashv1beta1.ResourceKindBackupConfiguration, cfg.Name); s != nil {
+			for _, phase := range sessionPhases {
+				ch <- prometheus.MustNewConstMetric(lastSessionPhaseDesc, prometheus.GaugeValue, boolValue(s.Status.Phase == phase), append(labels, string(phase))...)
+			}
+		}
+
+		overview, err := overviews.Build(ctx, cfg.DeepCopy())
+		if err != nil {
+			klog.V(4).Infof("skipping overview metrics of BackupConfiguration %s/%s, reason: %v", cfg.Namespace, cfg.Name, err)
+			continue
+		}
+		collectOverview(ch, overview, labels)
+
+		if sched, err := backups.ParseSchedule(cfg.Spec.Schedule); err == nil {
+			overdue := !cfg.Spec.Paused && backups.IsOverdue(sched, overview.Spec.LastBackupTime, cfg.CreationTimestamp.Time, now)
+			ch <- prometheus.MustNewConstMetric(overdueDesc, prometheus.GaugeValue, boolValue(overdue), labels...)
+		}
+	}
+}
+
+func collectOverview(ch chan<- prometheus.Metric, overview *uiapi.BackupOverview, labels []string) {
+	if overview.Spec.LastBackupTime != nil {
+		ch <- prometheus.MustNewConstMetric(lastSuccessDesc, prometheus.GaugeValue, float64(overview.Spec.LastBackupTime.Unix()), labels...)
+	}
+	if overview.Spec.UpcomingBackupTime != nil {
+		ch <- prometheus.MustNewConstMetric(nextScheduleDesc, prometheus.GaugeValue, float64(overview.Spec.UpcomingBackupTime.Unix()), labels...)
+	}
+	if size, err := shared.ParseSize(overview.Spec.DataSize); err == nil {
+		ch <- prometheus.MustNewConstMetric(repositorySizeDesc, prometheus.GaugeValue, size, labels...)
+	}
+	ch <- prometheus.MustNewConstMetric(snapshotCountDesc, prometheus.GaugeValue, float64(overview.Spec.NumberOfSnapshots), labels...)
+	ch <- prometheus.MustNewConstMetric(integrityDesc, prometheus.GaugeValue, boolValue(overview.Spec.DataIntegrity), labels...)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// Format is the shape of the JSON payload posted to a webhook
+type Format string
+
+const (
+	FormatGeneric Format = "generic"
+	FormatSlack   Format = "slack"
+)
+
+// Config lists the webhooks notified about backup state transitions
+type Config struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// Webhook is an endpoint along with the transitions routed to it
+type Webhook struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Format defaults to generic
+	Format  Format            `json:"format,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Namespaces routes the transitions of these namespaces only. All namespaces are routed if empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Conditions routes these conditions only. All conditions are routed if empty.
+	Conditions []evaluator.Condition `json:"conditions,omitempty"`
+	// SkipResolved suppresses the notifications about conditions that stopped firing
+	SkipResolved bool `json:"skipResolved,omitempty"`
+}
+
+// LoadConfig reads and validates a notification config file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse notification config %s, reason: %v", path, err)
+	}
+
+	names := sets.New[string]()
+	for i := range cfg.Webhooks {
+		w := &cfg.Webhooks[i]
+		if w.Name == "" {
+			return nil, fmt.Errorf("webhook %d of %s has no name", i, path)
+		}
+		if names.Has(w.Name) {
+			return nil, fmt.Errorf("duplicate webhook %q in %s", w.Name, path)
+		}
+		names.Insert(w.Name)
+
+		u, err := url.Parse(w.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhook %q has an invalid url", w.Name)
+		}
+		switch w.Format {
+		case "":
+			w.Format = FormatGeneric
+		case FormatGeneric, FormatSlack:
+		default:
+			return nil, fmt.Errorf("webhook %q has unknown format %q, must be one of: generic, slack", w.Name, w.Format)
+		}
+	}
+	return &cfg, nil
+}
+
+func (w *Webhook) routes(t evaluator.Transition) bool {
+	if !t.Firing && w.SkipResolved {
+		return false
+	}
+	if len(w.Namespaces) > 0 && !sets.New(w.Namespaces...).Has(t.Config.Namespace) {
+		return false
+	}
+	if len(w.Conditions) > 0 && !sets.New(w.Conditions...).Has(t.Condition) {
+		return false
+	}
+	return true
+}
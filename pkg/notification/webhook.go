@@ -0,0 +1,253 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	queueLength    = 100
+	requestTimeout = 10 * time.Second
+
+	statusFiring   = "Firing"
+	statusResolved = "Resolved"
+)
+
+// retryBackoff spreads five attempts over about half a minute
+var retryBackoff = wait.Backoff{
+	Duration: 2 * time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    5,
+}
+
+// Payload is the body posted to generic webhooks
+type Payload struct {
+	Cluster   string              `json:"cluster,omitempty"`
+	Condition evaluator.Condition `json:"condition"`
+	Status    string              `json:"status"`
+	Kind      string              `json:"kind"`
+	Namespace string              `json:"namespace"`
+	Name      string              `json:"name"`
+	Message   string              `json:"message,omitempty"`
+	Time      metav1.Time         `json:"time"`
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+type hook struct {
+	Webhook
+	queue chan Payload
+}
+
+// Notifier posts the transitions found by the evaluator to webhooks. Every
+// webhook has its own queue, so a slow endpoint does not delay the others.
+// The same notification is sent at most once per dedup window, and failed
+// deliveries are retried with exponential backoff.
+type Notifier struct {
+	cluster     string
+	dedupWindow time.Duration
+	client      *http.Client
+	hooks       []*hook
+
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+var (
+	_ evaluator.Sink   = &Notifier{}
+	_ manager.Runnable = &Notifier{}
+)
+
+func NewNotifier(cfg *Config, cluster string, dedupWindow time.Duration) *Notifier {
+	n := &Notifier{
+		cluster:     cluster,
+		dedupWindow: dedupWindow,
+		client:      &http.Client{Timeout: requestTimeout},
+		sent:        map[string]time.Time{},
+	}
+	for _, w := range cfg.Webhooks {
+		n.hooks = append(n.hooks, &hook{Webhook: w, queue: make(chan Payload, queueLength)})
+	}
+	return n
+}
+
+// Notify queues the transitions for the webhooks they are routed to
+func (n *Notifier) Notify(_ context.Context, transitions []evaluator.Transition) {
+	for _, t := range transitions {
+		p := n.payload(t)
+		for _, h := range n.hooks {
+			if !h.routes(t) || n.duplicate(h.Name, p) {
+				continue
+			}
+			select {
+			case h.queue <- p:
+			default:
+				klog.Warningf("dropping notification %s for %s/%s, the queue of webhook %s is full", p.Condition, p.Namespace, p.Name, h.Name)
+			}
+		}
+	}
+}
+
+// Start delivers the queued notifications until the context is done
+func (n *Notifier) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, h := range n.hooks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case p := <-h.queue:
+					if err := n.deliver(ctx, h, p); err != nil {
+						klog.Errorf("failed to notify webhook %s about %s of %s/%s, reason: %v", h.Name, p.Condition, p.Namespace, p.Name, err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (n *Notifier) payload(t evaluator.Transition) Payload {
+	status := statusResolved
+	if t.Firing {
+		status = statusFiring
+	}
+	return Payload{
+		Cluster:   n.cluster,
+		Condition: t.Condition,
+		Status:    status,
+		Kind:      stashv1beta1.ResourceKindBackupConfiguration,
+		Namespace: t.Config.Namespace,
+		Name:      t.Config.Name,
+		Message:   t.Message,
+		Time:      metav1.NewTime(t.Time),
+	}
+}
+
+// duplicate reports whether the same notification was sent to the webhook
+// within the dedup window, and records it otherwise
+func (n *Notifier) duplicate(webhook string, p Payload) bool {
+	key := fmt.Sprintf("%s/%s/%s/%s/%s", webhook, p.Namespace, p.Name, p.Condition, p.Status)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for k, t := range n.sent {
+		if p.Time.Sub(t) >= n.dedupWindow {
+			delete(n.sent, k)
+		}
+	}
+	if _, ok := n.sent[key]; ok {
+		return true
+	}
+	n.sent[key] = p.Time.Time
+	return false
+}
+
+func (n *Notifier) deliver(ctx context.Context, h *hook, p Payload) error {
+	var body any = p
+	if h.Format == FormatSlack {
+		body = slackPayload{Text: slackText(p)}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	err = wait.ExponentialBackoffWithContext(ctx, retryBackoff, func(ctx context.Context) (bool, error) {
+		retry, err := n.post(ctx, h, data)
+		if err == nil {
+			return true, nil
+		}
+		lastErr = err
+		if !retry {
+			return false, err
+		}
+		klog.V(3).Infof("retrying webhook %s, reason: %v", h.Name, err)
+		return false, nil
+	})
+	if wait.Interrupted(err) && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+// post sends a payload once and reports whether a failure may be retried
+func (n *Notifier) post(ctx context.Context, h *hook, data []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range h.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded with %s", resp.Status)
+	default:
+		return false, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+}
+
+func slackText(p Payload) string {
+	icon := ":red_circle:"
+	if p.Status == statusResolved {
+		icon = ":large_green_circle:"
+	}
+	target := fmt.Sprintf("%s %s/%s", p.Kind, p.Namespace, p.Name)
+	if p.Cluster != "" {
+		target += " in cluster " + p.Cluster
+	}
+	text := fmt.Sprintf("%s *[%s] %s* %s", icon, p.Status, p.Condition, target)
+	if p.Message != "" {
+		text += ": " + p.Message
+	}
+	return text
+}
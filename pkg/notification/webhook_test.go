@@ -0,0 +1,98 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/ui-server/pkg/evaluator"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestNotifier(t *testing.T) {
+	retryBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	var (
+		mu       sync.Mutex
+		attempts int
+		received []slackPayload
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var p slackPayload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Error(err)
+		}
+		received = append(received, p)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(&Config{
+		Webhooks: []Webhook{
+			{Name: "slack", URL: srv.URL, Format: FormatSlack, Namespaces: []string{"demo"}},
+		},
+	}, "prod", time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = n.Start(ctx) }()
+
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	cfg := &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "mysql"}}
+	other := &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "mysql"}}
+	transitions := []evaluator.Transition{
+		{Condition: evaluator.ConditionOverdue, Firing: true, Message: "no backup succeeded yet", Time: now, Config: cfg},
+		{Condition: evaluator.ConditionOverdue, Firing: true, Time: now, Config: other},
+	}
+	n.Notify(ctx, transitions)
+	// a duplicate within the dedup window is dropped
+	n.Notify(ctx, transitions)
+
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) > 0, nil
+	}); err != nil {
+		t.Fatal("webhook was not notified")
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := ":red_circle: *[Firing] Overdue* BackupConfiguration demo/mysql in cluster prod: no backup succeeded yet"
+	if len(received) != 1 || received[0].Text != expected {
+		t.Errorf("received %+v, expected a single notification %q", received, expected)
+	}
+	if attempts != 2 {
+		t.Errorf("webhook was called %d times, expected 2", attempts)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,24 +29,34 @@ import (
 	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
 	"stash.appscode.dev/apimachinery/apis/ui"
 	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/instrumentation"
+	"stash.appscode.dev/ui-server/pkg/shared"
 
 	"github.com/lnquy/cron"
 	rcron "github.com/robfig/cron/v3"
 	"gomodules.xyz/pointer"
+	batchv1 "k8s.io/api/batch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	kmapi "kmodules.xyz/client-go/api/v1"
 	mu "kmodules.xyz/client-go/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type BackupOverviewStorage struct {
-	kc        client.Client
+	kc client.Client
+	// uc writes the changes of an overview as the requester
+	uc        shared.UserClient
 	a         authorizer.Authorizer
 	gr        schema.GroupResource
 	convertor rest.TableConvertor
@@ -58,11 +69,16 @@ var (
 	_ rest.Getter                   = &BackupOverviewStorage{}
 	_ rest.Lister                   = &BackupOverviewStorage{}
 	_ rest.SingularNameProvider     = &BackupOverviewStorage{}
+	_ rest.Patcher                  = &BackupOverviewStorage{}
+	_ rest.GracefulDeleter          = &BackupOverviewStorage{}
 )
 
-func NewBackupOverviewStorage(kc client.Client, a authorizer.Authorizer) *BackupOverviewStorage {
+const overviewUIDPrefix = "bkovw-"
+
+func NewBackupOverviewStorage(kc client.Client, uc shared.UserClient, a authorizer.Authorizer) *BackupOverviewStorage {
 	return &BackupOverviewStorage{
 		kc: kc,
+		uc: uc,
 		a:  a,
 		gr: schema.GroupResource{
 			Group:    stashapi.GroupName,
@@ -127,7 +143,7 @@ func (r *BackupOverviewStorage) Get(ctx context.Context, name string, _ *metav1.
 	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, backupConfig); err != nil {
 		return nil, fmt.Errorf("failed to get BackupConfiguration, reason: %v", err)
 	}
-	return r.getBackupOverview(ctx, backupConfig.DeepCopy())
+	return GetBackupOverview(ctx, r.kc, backupConfig.DeepCopy())
 }
 
 func (r *BackupOverviewStorage) List(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
@@ -174,8 +190,9 @@ func (r *BackupOverviewStorage) List(ctx context.Context, options *internalversi
 	}
 
 	backupOverviews := make([]uiapi.BackupOverview, 0, len(backupCfgList.Items))
+	overviews := NewOverviewBuilder(r.kc)
 	for _, c := range backupCfgList.Items {
-		bo, err := r.getBackupOverview(ctx, c.DeepCopy())
+		bo, err := overviews.Build(ctx, c.DeepCopy())
 		if err != nil {
 			return nil, err
 		}
@@ -189,23 +206,217 @@ func (r *BackupOverviewStorage) List(ctx context.Context, options *internalversi
 	return result, nil
 }
 
+// Update writes the schedule and the paused state of an overview back to its
+// BackupConfiguration. The other fields of the overview are read-only.
+func (r *BackupOverviewStorage) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, _ rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, _ bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest("missing user info")
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            user,
+		Verb:            "update",
+		Namespace:       ns,
+		APIGroup:        r.gr.Group,
+		Resource:        r.gr.Resource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, why, err := r.a.Authorize(ctx, attrs)
+	if err != nil {
+		return nil, false, apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return nil, false, apierrors.NewForbidden(r.gr, name, errors.New(why))
+	}
+
+	backupConfig := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, backupConfig); err != nil {
+		return nil, false, err
+	}
+	overviews := NewOverviewBuilder(r.kc)
+	old, err := overviews.Build(ctx, backupConfig.DeepCopy())
+	if err != nil {
+		return nil, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, old)
+	if err != nil {
+		return nil, false, err
+	}
+	in, ok := obj.(*uiapi.BackupOverview)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uiapi.ResourceKindBackupOverview, obj))
+	}
+	if updateValidation != nil {
+		if err := updateValidation(ctx, obj.DeepCopyObject(), old); err != nil {
+			return nil, false, err
+		}
+	}
+
+	specPath := field.NewPath("spec")
+	schedule := overviewSchedule(in.Spec.Schedule)
+	errs := validateSchedule(specPath.Child("schedule"), schedule)
+	if in.Spec.Status != uiapi.BackupStatusActive && in.Spec.Status != uiapi.BackupStatusPaused {
+		errs = append(errs, field.NotSupported(specPath.Child("status"), in.Spec.Status, []string{string(uiapi.BackupStatusActive), string(uiapi.BackupStatusPaused)}))
+	}
+	if len(errs) > 0 {
+		return nil, false, apierrors.NewInvalid(uiapi.SchemeGroupVersion.WithKind(uiapi.ResourceKindBackupOverview).GroupKind(), name, errs)
+	}
+
+	backupConfig.Spec.Schedule = schedule
+	markPaused(backupConfig, in.Spec.Status == uiapi.BackupStatusPaused, user.GetName(), "")
+	uc, err := r.uc(user)
+	if err != nil {
+		return nil, false, apierrors.NewInternalError(err)
+	}
+	if err := updateBackupConfiguration(ctx, uc, r.gr, backupConfig, in.ResourceVersion, options); err != nil {
+		return nil, false, err
+	}
+	result, err := overviews.Build(ctx, backupConfig)
+	if err != nil {
+		return nil, false, err
+	}
+	return result, false, nil
+}
+
+// Delete deletes the BackupConfiguration of an overview and keeps its
+// Repository. Use the deletion subresource to delete the Repository as well.
+func (r *BackupOverviewStorage) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest("missing user info")
+	}
+
+	// authorized before the Get, so that the existence is not disclosed
+	if err := shared.Authorize(ctx, r.a, user, "delete", ns, r.gr, name); err != nil {
+		return nil, false, err
+	}
+	backupConfig := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, backupConfig); err != nil {
+		return nil, false, err
+	}
+	overview, err := GetBackupOverview(ctx, r.kc, backupConfig.DeepCopy())
+	if err != nil {
+		// the Repository may already be gone
+		overview = &uiapi.BackupOverview{ObjectMeta: *backupConfig.ObjectMeta.DeepCopy()}
+	}
+	if deleteValidation != nil {
+		if err := deleteValidation(ctx, overview); err != nil {
+			return nil, false, err
+		}
+	}
+
+	opts := &client.DeleteOptions{}
+	if options != nil {
+		raw := options.DeepCopy()
+		// preconditions refer to the overview
+		if raw.Preconditions != nil && raw.Preconditions.UID != nil {
+			uid := types.UID(strings.TrimPrefix(string(*raw.Preconditions.UID), overviewUIDPrefix))
+			raw.Preconditions.UID = &uid
+		}
+		opts.Raw = raw
+		opts.DryRun = options.DryRun
+	}
+	// the Repository is handled the same way as by the deletion subresource
+	if _, err := deleteBackupConfiguration(ctx, r.kc, r.a, user, backupConfig, deletionSpecFrom(ctx), opts); err != nil {
+		return nil, false, err
+	}
+	return overview, true, nil
+}
+
 func (r *BackupOverviewStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
 	return r.convertor.ConvertToTable(ctx, object, tableOptions)
 }
 
-func (r *BackupOverviewStorage) getBackupOverview(ctx context.Context, cfg *stashv1beta1.BackupConfiguration) (*uiapi.BackupOverview, error) {
-	repo, err := getRepository(ctx, r.kc, cfg)
+// GetBackupOverview summarizes a BackupConfiguration along with its Repository.
+// Use an OverviewBuilder to summarize many BackupConfigurations.
+func GetBackupOverview(ctx context.Context, kc client.Client, cfg *stashv1beta1.BackupConfiguration) (*uiapi.BackupOverview, error) {
+	return NewOverviewBuilder(kc).Build(ctx, cfg)
+}
+
+// OverviewBuilder summarizes BackupConfigurations. The BackupSessions and the
+// CronJobs are listed once per namespace, as the CronJobs are not cached and
+// the client of a member cluster caches nothing. A builder serves a single
+// request or scrape, it does not see later changes of the listed objects and
+// is not safe for concurrent use.
+type OverviewBuilder struct {
+	kc       client.Client
+	sessions map[string]sessionList
+	cronJobs map[string]cronJobList
+}
+
+type sessionList struct {
+	items []stashv1beta1.BackupSession
+	err   error
+}
+
+type cronJobList struct {
+	items []batchv1.CronJob
+	err   error
+}
+
+func NewOverviewBuilder(kc client.Client) *OverviewBuilder {
+	return &OverviewBuilder{
+		kc:       kc,
+		sessions: map[string]sessionList{},
+		cronJobs: map[string]cronJobList{},
+	}
+}
+
+func (b *OverviewBuilder) backupSessions(ctx context.Context, ns string) ([]stashv1beta1.BackupSession, error) {
+	l, ok := b.sessions[ns]
+	if !ok {
+		var sessions stashv1beta1.BackupSessionList
+		l.err = b.kc.List(ctx, &sessions, client.InNamespace(ns))
+		l.items = sessions.Items
+		b.sessions[ns] = l
+	}
+	return l.items, l.err
+}
+
+func (b *OverviewBuilder) backupCronJob(ctx context.Context, cfg *stashv1beta1.BackupConfiguration) (*batchv1.CronJob, error) {
+	l, ok := b.cronJobs[cfg.Namespace]
+	if !ok {
+		var cronJobs batchv1.CronJobList
+		l.err = b.kc.List(ctx, &cronJobs, client.InNamespace(cfg.Namespace))
+		l.items = cronJobs.Items
+		b.cronJobs[cfg.Namespace] = l
+	}
+	if l.err != nil {
+		return nil, l.err
+	}
+	return ownedCronJob(l.items, cfg.UID), nil
+}
+
+// Build summarizes a BackupConfiguration along with its Repository
+func (b *OverviewBuilder) Build(ctx context.Context, cfg *stashv1beta1.BackupConfiguration) (*uiapi.BackupOverview, error) {
+	kc := b.kc
+	start := time.Now()
+	repo, err := getRepository(ctx, kc, cfg)
+	instrumentation.ObserveOverviewPhase(instrumentation.OverviewPhaseRepository, start)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Repository, reason: %v", err)
 	}
 
+	start = time.Now()
 	exprDesc, _ := cron.NewDescriptor()
 	desc, err := exprDesc.ToDescription(cfg.Spec.Schedule, cron.Locale_en)
-	if err != nil {
-		return nil, err
+	var sched rcron.Schedule
+	if err == nil {
+		sched, err = ParseSchedule(cfg.Spec.Schedule)
 	}
-
-	sched, err := rcron.NewParser(rcron.Minute | rcron.Hour | rcron.Dom | rcron.Month | rcron.Dow).Parse(cfg.Spec.Schedule)
+	instrumentation.ObserveOverviewPhase(instrumentation.OverviewPhaseCron, start)
 	if err != nil {
 		return nil, err
 	}
@@ -225,10 +436,33 @@ func (r *BackupOverviewStorage) getBackupOverview(ctx context.Context, cfg *stas
 	}
 	if cfg.Spec.Paused {
 		result.Spec.Status = uiapi.BackupStatusPaused
+		if c := pausedCondition(cfg); c != nil {
+			result.Status.Conditions = append(append([]kmapi.Condition(nil), result.Status.Conditions...), *c)
+		}
 	} else {
 		result.Spec.Status = uiapi.BackupStatusActive
 	}
-	result.UID = "bkovw-" + cfg.GetUID()
+
+	start = time.Now()
+	sessions, err := b.backupSessions(ctx, cfg.Namespace)
+	instrumentation.ObserveOverviewPhase(instrumentation.OverviewPhaseSessions, start)
+	if err != nil {
+		klog.V(3).Infof("failed to list the BackupSessions of BackupConfiguration %s/%s, reason: %v", cfg.Namespace, cfg.Name, err)
+	} else if s := LatestBackupSession(sessions, stashv1beta1.ResourceKindBackupConfiguration, cfg.Name); s != nil {
+		result.Status.Conditions = append(append([]kmapi.Condition(nil), result.Status.Conditions...), lastSessionCondition(s))
+	}
+
+	start = time.Now()
+	cj, err := b.backupCronJob(ctx, cfg)
+	instrumentation.ObserveOverviewPhase(instrumentation.OverviewPhaseCronJob, start)
+	if err != nil {
+		// the overview is still useful without the CronJob, e.g. if the server may not list CronJobs
+		klog.V(3).Infof("failed to get the CronJob of BackupConfiguration %s/%s, reason: %v", cfg.Namespace, cfg.Name, err)
+	} else {
+		c := cronJobCondition(CheckCronJob(cfg, cj, time.Now()))
+		result.Status.Conditions = append(append([]kmapi.Condition(nil), result.Status.Conditions...), c)
+	}
+	result.UID = overviewUIDPrefix + cfg.GetUID()
 	// result.SelfLink = ""
 	result.ManagedFields = nil
 	result.OwnerReferences = nil
@@ -238,16 +472,76 @@ func (r *BackupOverviewStorage) getBackupOverview(ctx context.Context, cfg *stas
 	return result, nil
 }
 
-// Helper function to get the Repository for a Stash BackupConfiguration object
-func getRepository(ctx context.Context, kc client.Client, backupConfig *stashv1beta1.BackupConfiguration) (*stashv1alpha1.Repository, error) {
-	repoKey := client.ObjectKey{Name: backupConfig.Spec.Repository.Name, Namespace: backupConfig.Spec.Repository.Namespace}
-	if repoKey.Namespace == "" {
-		repoKey.Namespace = backupConfig.Namespace
+// overviewSchedule extracts the cron expression from the schedule of an
+// overview, which is shown as the quoted expression followed by its description.
+func overviewSchedule(s string) string {
+	s = strings.TrimSpace(s)
+	if q, err := strconv.QuotedPrefix(s); err == nil {
+		if expr, err := strconv.Unquote(q); err == nil {
+			return expr
+		}
+	}
+	return s
+}
+
+// pausedCondition describes who paused a BackupConfiguration and why, if it
+// was paused through this server.
+func pausedCondition(cfg *stashv1beta1.BackupConfiguration) *kmapi.Condition {
+	status := pauseStatus(cfg)
+	if status.PausedBy == "" {
+		return nil
+	}
+	msg := "Paused by " + status.PausedBy
+	if status.Reason != "" {
+		msg += ": " + status.Reason
+	}
+	c := kmapi.Condition{
+		Type:    uisrv.BackupPausedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "PausedByUser",
+		Message: msg,
+	}
+	if status.PausedAt != nil {
+		c.LastTransitionTime = *status.PausedAt
+	}
+	return &c
+}
+
+// lastSessionCondition tells whether the latest BackupSession succeeded. The
+// phase of a session that has not completed yet is not a failure.
+func lastSessionCondition(s *stashv1beta1.BackupSession) kmapi.Condition {
+	c := kmapi.Condition{
+		Type:               uisrv.BackupLastSessionCondition,
+		Status:             metav1.ConditionUnknown,
+		Reason:             string(s.Status.Phase),
+		Message:            "BackupSession " + s.Name,
+		LastTransitionTime: s.CreationTimestamp,
 	}
+	switch s.Status.Phase {
+	case stashv1beta1.BackupSessionSucceeded:
+		c.Status = metav1.ConditionTrue
+	case stashv1beta1.BackupSessionFailed:
+		c.Status = metav1.ConditionFalse
+	case "":
+		c.Reason = string(stashv1beta1.BackupSessionPending)
+	}
+	return c
+}
 
+// Helper function to get the Repository for a Stash BackupConfiguration object
+func getRepository(ctx context.Context, kc client.Client, backupConfig *stashv1beta1.BackupConfiguration) (*stashv1alpha1.Repository, error) {
 	repo := &stashv1alpha1.Repository{}
-	if err := kc.Get(ctx, repoKey, repo); err != nil {
+	if err := kc.Get(ctx, repositoryKey(backupConfig), repo); err != nil {
 		return nil, err
 	}
 	return repo, nil
 }
+
+// repositoryKey returns the key of the Repository of a BackupConfiguration
+func repositoryKey(backupConfig *stashv1beta1.BackupConfiguration) client.ObjectKey {
+	key := client.ObjectKey{Name: backupConfig.Spec.Repository.Name, Namespace: backupConfig.Spec.Repository.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = backupConfig.Namespace
+	}
+	return key
+}
@@ -0,0 +1,216 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/instrumentation"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/registry/rest"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func TestOverviewSchedule(t *testing.T) {
+	cases := map[string]string{
+		`"*/5 * * * *" (Every 5 minutes)`: "*/5 * * * *",
+		`0 2 * * *`:                       "0 2 * * *",
+		` "@daily"`:                       "@daily",
+	}
+	for in, expected := range cases {
+		if got := overviewSchedule(in); got != expected {
+			t.Errorf("overviewSchedule(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+// cronPhaseCount returns the number of observed cron phases of a BackupOverview
+func cronPhaseCount(t *testing.T) uint64 {
+	t.Helper()
+	families, err := ctrlmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() != "stash_ui_overview_phase_duration_seconds" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "phase" && l.GetValue() == instrumentation.OverviewPhaseCron {
+					return m.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestOverviewObservesInvalidSchedule(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "demo"},
+		Spec:       stashv1beta1.BackupConfigurationSpec{Schedule: "every hour"},
+	}
+	cfg.Spec.Repository.Name = "app-repo"
+	repo := &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "app-repo", Namespace: "demo"}}
+	kc := testclient.New(t, cfg, repo)
+
+	before := cronPhaseCount(t)
+	if _, err := GetBackupOverview(context.TODO(), kc, cfg); err == nil {
+		t.Fatal("expected an error for an invalid schedule")
+	}
+	if got := cronPhaseCount(t) - before; got != 1 {
+		t.Errorf("observed cron phases = %d, want 1", got)
+	}
+}
+
+func TestOverviewUpdate(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "demo"},
+		Spec:       stashv1beta1.BackupConfigurationSpec{Schedule: "0 * * * *"},
+	}
+	cfg.Spec.Repository.Name = "app-repo"
+	repo := &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "app-repo", Namespace: "demo"}}
+	in := &uiapi.BackupOverview{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "demo"},
+		Spec:       uiapi.BackupOverviewSpec{Schedule: "0 2 * * *", Status: uiapi.BackupStatusPaused},
+	}
+
+	for _, c := range []struct {
+		name   string
+		denied []string
+	}{
+		{"allowed", nil},
+		{"no update of the configuration", []string{"update/" + stashv1beta1.ResourcePluralBackupConfiguration}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			kc := testclient.New(t, cfg.DeepCopy(), repo.DeepCopy())
+			var users []string
+			r := NewBackupOverviewStorage(kc, recordingUserClient(kc, &users), denyResources(c.denied...))
+			_, _, err := r.Update(requestContext("demo"), "app", rest.DefaultUpdatedObjectInfo(in.DeepCopy()), nil, nil, false, &metav1.UpdateOptions{})
+			got := &stashv1beta1.BackupConfiguration{}
+			if err := kc.Get(context.TODO(), client.ObjectKeyFromObject(cfg), got); err != nil {
+				t.Fatal(err)
+			}
+			if c.denied != nil {
+				if !apierrors.IsForbidden(err) {
+					t.Errorf("expected forbidden, got %v", err)
+				}
+				if got.Spec.Schedule != cfg.Spec.Schedule || got.Spec.Paused {
+					t.Errorf("the configuration must be left alone, got %+v", got.Spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Spec.Schedule != "0 2 * * *" || !got.Spec.Paused {
+				t.Errorf("the configuration must be rescheduled and paused, got %+v", got.Spec)
+			}
+			if len(users) != 1 || users[0] != "alice" {
+				t.Errorf("the update must be sent as alice, got %v", users)
+			}
+		})
+	}
+}
+
+func TestOverviewLastSessionCondition(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "demo"},
+		Spec:       stashv1beta1.BackupConfigurationSpec{Schedule: "0 * * * *"},
+	}
+	cfg.Spec.Repository.Name = "app-repo"
+	repo := &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "app-repo", Namespace: "demo"}}
+	session := func(name string, created time.Time, phase stashv1beta1.BackupSessionPhase) *stashv1beta1.BackupSession {
+		return &stashv1beta1.BackupSession{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo", CreationTimestamp: metav1.NewTime(created)},
+			Spec:       stashv1beta1.BackupSessionSpec{Invoker: stashv1beta1.BackupInvokerRef{Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: "app"}},
+			Status:     stashv1beta1.BackupSessionStatus{Phase: phase},
+		}
+	}
+	now := time.Now().Truncate(time.Second)
+	kc := testclient.New(t, cfg, repo,
+		session("app-1", now.Add(-2*time.Hour), stashv1beta1.BackupSessionSucceeded),
+		session("app-2", now.Add(-time.Hour), stashv1beta1.BackupSessionFailed),
+	)
+
+	overview, err := GetBackupOverview(context.TODO(), kc, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got *kmapi.Condition
+	for i, c := range overview.Status.Conditions {
+		if c.Type == uisrv.BackupLastSessionCondition {
+			got = &overview.Status.Conditions[i]
+		}
+	}
+	if got == nil || got.Status != metav1.ConditionFalse || got.Reason != string(stashv1beta1.BackupSessionFailed) || got.Message != "BackupSession app-2" {
+		t.Errorf("unexpected last session condition %+v", got)
+	}
+}
+
+func TestOverviewBuilderListsOncePerNamespace(t *testing.T) {
+	var objs []client.Object
+	for _, ns := range []string{"demo", "prod"} {
+		for _, name := range []string{"app", "db"} {
+			cfg := &stashv1beta1.BackupConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+				Spec:       stashv1beta1.BackupConfigurationSpec{Schedule: "0 * * * *"},
+			}
+			cfg.Spec.Repository.Name = "repo"
+			objs = append(objs, cfg)
+		}
+		objs = append(objs, &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: ns}})
+	}
+	lists := map[string]int{}
+	kc := testclient.NewBuilder(t).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			switch list.(type) {
+			case *batchv1.CronJobList:
+				lists["cronjobs"]++
+			case *stashv1beta1.BackupSessionList:
+				lists["backupsessions"]++
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+
+	overviews := NewOverviewBuilder(kc)
+	for _, obj := range objs {
+		if cfg, ok := obj.(*stashv1beta1.BackupConfiguration); ok {
+			if _, err := overviews.Build(context.TODO(), cfg); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if lists["cronjobs"] != 2 || lists["backupsessions"] != 2 {
+		t.Errorf("lists = %v, want each listed once per namespace", lists)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type BulkBackupPauseStorage struct {
+	kc client.Client
+	// uc patches the invokers as the requester
+	uc        shared.UserClient
+	a         authorizer.Authorizer
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &BulkBackupPauseStorage{}
+	_ rest.Scoper                   = &BulkBackupPauseStorage{}
+	_ rest.Storage                  = &BulkBackupPauseStorage{}
+	_ rest.Creater                  = &BulkBackupPauseStorage{}
+	_ rest.SingularNameProvider     = &BulkBackupPauseStorage{}
+)
+
+func NewBulkBackupPauseStorage(kc client.Client, uc shared.UserClient, a authorizer.Authorizer) *BulkBackupPauseStorage {
+	return &BulkBackupPauseStorage{
+		kc: kc,
+		uc: uc,
+		a:  a,
+		convertor: rest.NewDefaultTableConvertor(schema.GroupResource{
+			Group:    ui.GroupName,
+			Resource: uisrv.ResourceBulkBackupPauses,
+		}),
+	}
+}
+
+func (r *BulkBackupPauseStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBulkBackupPause)
+}
+
+func (r *BulkBackupPauseStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindBulkBackupPause)
+}
+
+func (r *BulkBackupPauseStorage) NamespaceScoped() bool {
+	return false
+}
+
+func (r *BulkBackupPauseStorage) New() runtime.Object {
+	return &uisrv.BulkBackupPause{}
+}
+
+func (r *BulkBackupPauseStorage) Destroy() {}
+
+func (r *BulkBackupPauseStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	u, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BulkBackupPause)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBulkBackupPause, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	// an empty selector would select every invoker, which must be asked for explicitly
+	emptySelector := in.Spec.Selector == nil || (len(in.Spec.Selector.MatchLabels) == 0 && len(in.Spec.Selector.MatchExpressions) == 0)
+	switch {
+	case in.Spec.All && !emptySelector:
+		return nil, apierrors.NewBadRequest("selector and all are mutually exclusive")
+	case !in.Spec.All && emptySelector:
+		return nil, apierrors.NewBadRequest("a non-empty selector is required, set all to select every invoker")
+	}
+	selector := labels.Everything()
+	if !emptySelector {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(in.Spec.Selector)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+	}
+	kinds := in.Spec.Kinds
+	if len(kinds) == 0 {
+		kinds = []string{stashv1beta1.ResourceKindBackupConfiguration, stashv1beta1.ResourceKindBackupBatch}
+	}
+	var dryRun []string
+	if options != nil {
+		dryRun = options.DryRun
+	}
+
+	uc, err := r.uc(u)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	result := in.DeepCopy()
+	result.CreationTimestamp = metav1.Now()
+	for _, kind := range kinds {
+		invokers, gr, err := r.listInvokers(ctx, kind, in.Spec.Namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, invoker := range invokers {
+			item := uisrv.BulkBackupPauseItem{
+				Invoker: kmapi.TypedObjectReference{
+					APIGroup:  stashapi.GroupName,
+					Kind:      kind,
+					Namespace: invoker.GetNamespace(),
+					Name:      invoker.GetName(),
+				},
+				Result: uisrv.BulkBackupPauseUnchanged,
+			}
+			err := shared.Authorize(ctx, r.a, u, "get", invoker.GetNamespace(), gr, invoker.GetName())
+			switch {
+			case apierrors.IsForbidden(err):
+				// only counted, the user may not learn the name
+				result.Status.Forbidden++
+				continue
+			case err != nil:
+				return nil, err
+			}
+			var changed bool
+			err = shared.Authorize(ctx, r.a, u, "patch", invoker.GetNamespace(), gr, invoker.GetName())
+			if err == nil {
+				changed, err = setPaused(ctx, uc, u, invoker, in.Spec.Paused, in.Spec.Reason, dryRun)
+			}
+			switch {
+			case apierrors.IsForbidden(err):
+				item.Result = uisrv.BulkBackupPauseForbidden
+				item.Message = err.Error()
+			case err != nil:
+				item.Result = uisrv.BulkBackupPauseError
+				item.Message = err.Error()
+			case changed:
+				item.Result = uisrv.BulkBackupPauseChanged
+			}
+			result.Status.Items = append(result.Status.Items, item)
+		}
+	}
+	return result, nil
+}
+
+func (r *BulkBackupPauseStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
+
+func (r *BulkBackupPauseStorage) listInvokers(ctx context.Context, kind, ns string, selector labels.Selector) ([]client.Object, schema.GroupResource, error) {
+	_, gr, err := newInvoker(kind)
+	if err != nil {
+		return nil, schema.GroupResource{}, apierrors.NewBadRequest(err.Error())
+	}
+
+	opts := &client.ListOptions{Namespace: ns, LabelSelector: selector}
+	var invokers []client.Object
+	switch kind {
+	case stashv1beta1.ResourceKindBackupConfiguration:
+		list := stashv1beta1.BackupConfigurationList{}
+		if err := r.kc.List(ctx, &list, opts); err != nil {
+			return nil, schema.GroupResource{}, err
+		}
+		for i := range list.Items {
+			invokers = append(invokers, &list.Items[i])
+		}
+	case stashv1beta1.ResourceKindBackupBatch:
+		list := stashv1beta1.BackupBatchList{}
+		if err := r.kc.List(ctx, &list, opts); err != nil {
+			return nil, schema.GroupResource{}, err
+		}
+		for i := range list.Items {
+			invokers = append(invokers, &list.Items[i])
+		}
+	}
+	return invokers, gr, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"testing"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestBulkPause(t *testing.T) {
+	cfg := func(ns, name, team string) *stashv1beta1.BackupConfiguration {
+		return &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{"team": team}}}
+	}
+	kc := testclient.New(t, cfg("demo", "a", "db"), cfg("demo", "b", "web"), cfg("prod", "c", "db"))
+	// alice may not patch in namespace prod
+	a := authorizer.AuthorizerFunc(func(_ context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+		if attrs.GetVerb() == "patch" && attrs.GetNamespace() == "prod" {
+			return authorizer.DecisionDeny, "denied", nil
+		}
+		return authorizer.DecisionAllow, "", nil
+	})
+	var users []string
+	r := NewBulkBackupPauseStorage(kc, recordingUserClient(kc, &users), a)
+	ctx := requestContext("")
+
+	for name, spec := range map[string]uisrv.BulkBackupPauseSpec{
+		"no selector":        {Paused: true},
+		"empty selector":     {Paused: true, Selector: &metav1.LabelSelector{}},
+		"selector and all":   {Paused: true, All: true, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "db"}}},
+		"invalid expression": {Paused: true, Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Is"}}}},
+	} {
+		if _, err := r.Create(ctx, &uisrv.BulkBackupPause{Spec: spec}, nil, &metav1.CreateOptions{}); !apierrors.IsBadRequest(err) {
+			t.Errorf("%s: expected a bad request, got %v", name, err)
+		}
+	}
+
+	obj, err := r.Create(ctx, &uisrv.BulkBackupPause{Spec: uisrv.BulkBackupPauseSpec{
+		Kinds:    []string{stashv1beta1.ResourceKindBackupConfiguration},
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "db"}},
+		Paused:   true,
+	}}, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := map[string]uisrv.BulkBackupPauseResult{}
+	for _, item := range obj.(*uisrv.BulkBackupPause).Status.Items {
+		results[item.Invoker.Namespace+"/"+item.Invoker.Name] = item.Result
+	}
+	want := map[string]uisrv.BulkBackupPauseResult{"demo/a": uisrv.BulkBackupPauseChanged, "prod/c": uisrv.BulkBackupPauseForbidden}
+	if len(results) != len(want) || results["demo/a"] != want["demo/a"] || results["prod/c"] != want["prod/c"] {
+		t.Errorf("results = %v, want %v", results, want)
+	}
+	if len(users) != 1 || users[0] != "alice" {
+		t.Errorf("the patches must be sent as alice, got %v", users)
+	}
+
+	got := &stashv1beta1.BackupConfiguration{}
+	if err := kc.Get(context.TODO(), client.ObjectKey{Namespace: "prod", Name: "c"}, got); err != nil || got.Spec.Paused {
+		t.Errorf("prod/c must not be paused, err: %v", err)
+	}
+
+	obj, err = r.Create(ctx, &uisrv.BulkBackupPause{Spec: uisrv.BulkBackupPauseSpec{
+		Namespace: "demo",
+		Kinds:     []string{stashv1beta1.ResourceKindBackupConfiguration},
+		All:       true,
+		Paused:    true,
+	}}, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := obj.(*uisrv.BulkBackupPause).Status.Items; len(items) != 2 {
+		t.Errorf("all must select both invokers of namespace demo, got %+v", items)
+	}
+
+	// the invokers that alice may not get are only counted
+	r = NewBulkBackupPauseStorage(kc, recordingUserClient(kc, &users), denyResources("get/"+stashv1beta1.ResourcePluralBackupConfiguration))
+	obj, err = r.Create(ctx, &uisrv.BulkBackupPause{Spec: uisrv.BulkBackupPauseSpec{
+		Kinds:  []string{stashv1beta1.ResourceKindBackupConfiguration},
+		All:    true,
+		Paused: false,
+	}}, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := obj.(*uisrv.BulkBackupPause).Status; len(status.Items) != 0 || status.Forbidden != 3 {
+		t.Errorf("expected 3 forbidden invokers without names, got %+v", status)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// requestContext returns the context of a request of alice in namespace ns
+func requestContext(ns string) context.Context {
+	ctx := apirequest.WithNamespace(context.TODO(), ns)
+	return apirequest.WithUser(ctx, &user.DefaultInfo{Name: "alice"})
+}
+
+// denyResources allows everything but the listed verb/resource pairs, e.g. "get/repositories"
+func denyResources(denied ...string) authorizer.Authorizer {
+	return authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		for _, d := range denied {
+			if d == a.GetVerb()+"/"+a.GetResource() {
+				return authorizer.DecisionDeny, "denied", nil
+			}
+		}
+		return authorizer.DecisionAllow, "", nil
+	})
+}
@@ -0,0 +1,280 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/clusters"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterBackupOverviewStorage serves the BackupOverviews of the local and the
+// member clusters as one cluster scoped list. Access is granted by the RBAC
+// rules for clusterbackupoverviews in the local cluster, the member clusters
+// are read with the identity of their kubeconfig.
+type ClusterBackupOverviewStorage struct {
+	clusters  *clusters.Set
+	a         authorizer.Authorizer
+	gr        schema.GroupResource
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &ClusterBackupOverviewStorage{}
+	_ rest.Scoper                   = &ClusterBackupOverviewStorage{}
+	_ rest.Storage                  = &ClusterBackupOverviewStorage{}
+	_ rest.Getter                   = &ClusterBackupOverviewStorage{}
+	_ rest.Lister                   = &ClusterBackupOverviewStorage{}
+	_ rest.SingularNameProvider     = &ClusterBackupOverviewStorage{}
+)
+
+func NewClusterBackupOverviewStorage(set *clusters.Set, a authorizer.Authorizer) *ClusterBackupOverviewStorage {
+	gr := schema.GroupResource{
+		Group:    ui.GroupName,
+		Resource: uisrv.ResourceClusterBackupOverviews,
+	}
+	return &ClusterBackupOverviewStorage{
+		clusters:  set,
+		a:         a,
+		gr:        gr,
+		convertor: rest.NewDefaultTableConvertor(gr),
+	}
+}
+
+func (r *ClusterBackupOverviewStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindClusterBackupOverview)
+}
+
+func (r *ClusterBackupOverviewStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindClusterBackupOverview)
+}
+
+func (r *ClusterBackupOverviewStorage) NamespaceScoped() bool {
+	return false
+}
+
+func (r *ClusterBackupOverviewStorage) New() runtime.Object {
+	return &uisrv.ClusterBackupOverview{}
+}
+
+func (r *ClusterBackupOverviewStorage) Destroy() {}
+
+func (r *ClusterBackupOverviewStorage) NewList() runtime.Object {
+	return &uisrv.ClusterBackupOverviewList{}
+}
+
+func (r *ClusterBackupOverviewStorage) authorize(ctx context.Context, verb, name string) error {
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return apierrors.NewBadRequest("missing user info")
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            user,
+		Verb:            verb,
+		APIGroup:        r.gr.Group,
+		Resource:        r.gr.Resource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, why, err := r.a.Authorize(ctx, attrs)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return apierrors.NewForbidden(r.gr, name, errors.New(why))
+	}
+	return nil
+}
+
+func (r *ClusterBackupOverviewStorage) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	if err := r.authorize(ctx, "get", name); err != nil {
+		return nil, err
+	}
+
+	// cluster names and namespaces are DNS-1123 labels, the name of the
+	// BackupConfiguration is the rest and may contain dots
+	parts := strings.SplitN(name, ".", 3)
+	if len(parts) != 3 {
+		return nil, apierrors.NewNotFound(r.gr, name)
+	}
+	m := r.clusters.Get(parts[0])
+	if m == nil {
+		return nil, apierrors.NewNotFound(r.gr, name)
+	}
+	if !m.Reachable() {
+		return nil, apierrors.NewServiceUnavailable(fmt.Sprintf("cluster %s is unreachable", m.Name))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clusters.Timeout)
+	defer cancel()
+
+	backupConfig := &stashv1beta1.BackupConfiguration{}
+	if err := m.Client.Get(ctx, client.ObjectKey{Namespace: parts[1], Name: parts[2]}, backupConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, apierrors.NewNotFound(r.gr, name)
+		}
+		return nil, fmt.Errorf("failed to get BackupConfiguration from cluster %s, reason: %v", m.Name, err)
+	}
+	bo, err := GetBackupOverview(ctx, m.Client, backupConfig)
+	if err != nil {
+		return nil, err
+	}
+	out := newClusterBackupOverview(m.Name, bo)
+	return &out, nil
+}
+
+// List merges the overviews of all reachable clusters. A cluster that can not
+// be reached is left out with a warning instead of failing the whole list.
+// The ui.stash.appscode.com/cluster label selects clusters, the rest of the
+// label selector is matched against the BackupConfigurations.
+func (r *ClusterBackupOverviewStorage) List(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+	if err := r.authorize(ctx, "list", ""); err != nil {
+		return nil, err
+	}
+
+	clusterSel, sel := labels.Everything(), labels.Everything()
+	if options != nil && options.LabelSelector != nil {
+		clusterSel, sel = splitClusterSelector(options.LabelSelector)
+	}
+
+	members := r.clusters.Members()
+	results := make([][]uisrv.ClusterBackupOverview, len(members))
+	warnings := make([][]string, len(members))
+	errs := make([]error, len(members))
+	var wg sync.WaitGroup
+	for i, m := range members {
+		if !clusterSel.Matches(labels.Set{uisrv.LabelCluster: m.Name}) {
+			continue
+		}
+		if !m.Reachable() {
+			warning.AddWarning(ctx, "", fmt.Sprintf("cluster %s is unreachable, its backups are not listed", m.Name))
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], warnings[i], errs[i] = listClusterBackupOverviews(ctx, m, sel)
+		}()
+	}
+	wg.Wait()
+
+	result := &uisrv.ClusterBackupOverviewList{}
+	for i, m := range members {
+		for _, w := range warnings[i] {
+			warning.AddWarning(ctx, "", w)
+		}
+		if errs[i] != nil {
+			if ctx.Err() == nil && clusters.IsUnreachable(errs[i]) {
+				m.MarkUnreachable(errs[i])
+			}
+			warning.AddWarning(ctx, "", fmt.Sprintf("failed to list backups of cluster %s: %v", m.Name, errs[i]))
+			continue
+		}
+		result.Items = append(result.Items, results[i]...)
+	}
+	return result, nil
+}
+
+func (r *ClusterBackupOverviewStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
+
+// listClusterBackupOverviews lists the overviews of a member cluster. An error
+// means that the member could not be reached, a BackupConfiguration whose
+// overview fails is left out with a warning.
+func listClusterBackupOverviews(ctx context.Context, m *clusters.Member, sel labels.Selector) ([]uisrv.ClusterBackupOverview, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, clusters.Timeout)
+	defer cancel()
+
+	var list stashv1beta1.BackupConfigurationList
+	if err := m.Client.List(ctx, &list, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, nil, err
+	}
+	out := make([]uisrv.ClusterBackupOverview, 0, len(list.Items))
+	var warnings []string
+	overviews := NewOverviewBuilder(m.Client)
+	for i := range list.Items {
+		bo, err := overviews.Build(ctx, &list.Items[i])
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, err
+			}
+			warnings = append(warnings, fmt.Sprintf("skipped BackupConfiguration %s/%s of cluster %s: %v", list.Items[i].Namespace, list.Items[i].Name, m.Name, err))
+			continue
+		}
+		out = append(out, newClusterBackupOverview(m.Name, bo))
+	}
+	return out, warnings, nil
+}
+
+func newClusterBackupOverview(cluster string, bo *uiapi.BackupOverview) uisrv.ClusterBackupOverview {
+	out := uisrv.ClusterBackupOverview{
+		ObjectMeta: *bo.ObjectMeta.DeepCopy(),
+		Spec: uisrv.ClusterBackupOverviewSpec{
+			Cluster:   cluster,
+			Namespace: bo.Namespace,
+			Name:      bo.Name,
+			Overview:  bo.Spec,
+		},
+		Status: bo.Status,
+	}
+	out.Name = strings.Join([]string{cluster, bo.Namespace, bo.Name}, ".")
+	out.Namespace = ""
+	// resource versions of different clusters can not be compared
+	out.ResourceVersion = ""
+	if out.Labels == nil {
+		out.Labels = map[string]string{}
+	}
+	out.Labels[uisrv.LabelCluster] = cluster
+	return out
+}
+
+// splitClusterSelector separates the requirements on the cluster label from
+// the rest of the selector.
+func splitClusterSelector(sel labels.Selector) (clusterSel, rest labels.Selector) {
+	clusterSel, rest = labels.NewSelector(), labels.NewSelector()
+	reqs, _ := sel.Requirements()
+	for _, req := range reqs {
+		if req.Key() == uisrv.LabelCluster {
+			clusterSel = clusterSel.Add(req)
+		} else {
+			rest = rest.Add(req)
+		}
+	}
+	return clusterSel, rest
+}
@@ -0,0 +1,134 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/clusters"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/warning"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+type warningRecorder []string
+
+func (w *warningRecorder) AddWarning(_, text string) {
+	*w = append(*w, text)
+}
+
+func TestListClusterBackupOverviewsSkipsBrokenConfigurations(t *testing.T) {
+	newConfig := func(name string) *stashv1beta1.BackupConfiguration {
+		return &stashv1beta1.BackupConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo"},
+			Spec: stashv1beta1.BackupConfigurationSpec{
+				Repository: kmapi.ObjectReference{Name: name + "-repo"},
+				Schedule:   "0 * * * *",
+			},
+		}
+	}
+	// the Repository of broken does not exist
+	repo := &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "app-repo", Namespace: "demo"}}
+	local := clusters.NewLocal("local", testclient.New(t, newConfig("app"), newConfig("broken"), repo))
+	r := NewClusterBackupOverviewStorage(clusters.NewSet(local), denyResources())
+
+	var warnings warningRecorder
+	ctx := warning.WithWarningRecorder(requestContext(""), &warnings)
+	obj, err := r.List(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := obj.(*uisrv.ClusterBackupOverviewList)
+	if len(list.Items) != 1 || list.Items[0].Name != "local.demo.app" {
+		t.Errorf("unexpected items %v", list.Items)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "demo/broken") {
+		t.Errorf("unexpected warnings %v", warnings)
+	}
+	if !local.Reachable() {
+		t.Error("a broken BackupConfiguration must not mark the cluster unreachable")
+	}
+}
+
+func TestListMarksOnlyUnreachableClusters(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	cases := []struct {
+		name      string
+		err       error
+		cancel    bool
+		reachable bool
+	}{
+		{name: "connection refused", err: refused, reachable: false},
+		{name: "forbidden", err: apierrors.NewForbidden(stashv1beta1.Resource(stashv1beta1.ResourcePluralBackupConfiguration), "", errors.New("denied")), reachable: true},
+		{name: "cancelled request", err: context.Canceled, cancel: true, reachable: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kc := testclient.NewBuilder(t).WithInterceptorFuncs(interceptor.Funcs{
+				List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+					return c.err
+				},
+			}).Build()
+			local := clusters.NewLocal("local", kc)
+			r := NewClusterBackupOverviewStorage(clusters.NewSet(local), denyResources())
+
+			ctx, cancel := context.WithCancel(requestContext(""))
+			defer cancel()
+			if c.cancel {
+				cancel()
+			}
+			if _, err := r.List(ctx, nil); err != nil {
+				t.Fatal(err)
+			}
+			if local.Reachable() != c.reachable {
+				t.Errorf("reachable = %v, want %v", local.Reachable(), c.reachable)
+			}
+		})
+	}
+}
+
+func TestGetDottedName(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "app.v2", Namespace: "demo"},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			Repository: kmapi.ObjectReference{Name: "app-repo"},
+			Schedule:   "0 * * * *",
+		},
+	}
+	repo := &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "app-repo", Namespace: "demo"}}
+	r := NewClusterBackupOverviewStorage(clusters.NewSet(clusters.NewLocal("local", testclient.New(t, cfg, repo))), denyResources())
+
+	obj, err := r.Get(requestContext(""), "local.demo.app.v2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := obj.(*uisrv.ClusterBackupOverview); got.Spec.Name != "app.v2" || got.Spec.Namespace != "demo" {
+		t.Errorf("unexpected overview %s/%s", got.Spec.Namespace, got.Spec.Name)
+	}
+}
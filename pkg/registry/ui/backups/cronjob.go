@@ -0,0 +1,247 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackupCronJobStorage implements the cronjob subresource of BackupOverview
+type BackupCronJobStorage struct {
+	kc client.Client
+	a  authorizer.Authorizer
+	gr schema.GroupResource
+}
+
+var (
+	_ rest.Storage                  = &BackupCronJobStorage{}
+	_ rest.Getter                   = &BackupCronJobStorage{}
+	_ rest.GroupVersionKindProvider = &BackupCronJobStorage{}
+)
+
+func NewBackupCronJobStorage(kc client.Client, a authorizer.Authorizer) *BackupCronJobStorage {
+	return &BackupCronJobStorage{
+		kc: kc,
+		a:  a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupConfiguration,
+		},
+	}
+}
+
+func (r *BackupCronJobStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupCronJob)
+}
+
+func (r *BackupCronJobStorage) New() runtime.Object {
+	return &uisrv.BackupCronJob{}
+}
+
+func (r *BackupCronJobStorage) Destroy() {}
+
+func (r *BackupCronJobStorage) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            user,
+		Verb:            "get",
+		Namespace:       ns,
+		APIGroup:        r.gr.Group,
+		Resource:        r.gr.Resource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, why, err := r.a.Authorize(ctx, attrs)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return nil, apierrors.NewForbidden(r.gr, name, errors.New(why))
+	}
+
+	cfg := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, cfg); err != nil {
+		return nil, err
+	}
+	cj, err := BackupCronJob(ctx, r.kc, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &uisrv.BackupCronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              cfg.Name,
+			Namespace:         cfg.Namespace,
+			UID:               cfg.UID,
+			CreationTimestamp: cfg.CreationTimestamp,
+		},
+		Status: CheckCronJob(cfg, cj, time.Now()),
+	}, nil
+}
+
+// cronJobFireGrace is the time a CronJob may take to fire after a scheduled time
+const cronJobFireGrace = 5 * time.Minute
+
+// annotationCronJobScheduledTimestamp is set on the Jobs created by a CronJob since Kubernetes 1.28
+const annotationCronJobScheduledTimestamp = "batch.kubernetes.io/cronjob-scheduled-timestamp"
+
+// BackupCronJob returns the CronJob created by the Stash operator for a
+// BackupConfiguration, or nil if there is none. The client of the apiserver
+// does not cache CronJobs, so they are listed in the namespace of the
+// configuration as the server, which must be allowed to list cronjobs.
+func BackupCronJob(ctx context.Context, kc client.Reader, cfg *stashv1beta1.BackupConfiguration) (*batchv1.CronJob, error) {
+	var cronJobs batchv1.CronJobList
+	if err := kc.List(ctx, &cronJobs, client.InNamespace(cfg.Namespace)); err != nil {
+		return nil, err
+	}
+	return ownedCronJob(cronJobs.Items, cfg.UID), nil
+}
+
+// ownedCronJob returns the CronJob owned by uid, or nil if there is none
+func ownedCronJob(cronJobs []batchv1.CronJob, uid types.UID) *batchv1.CronJob {
+	for i := range cronJobs {
+		if ownedBy(cronJobs[i].OwnerReferences, uid) {
+			return &cronJobs[i]
+		}
+	}
+	return nil
+}
+
+// CronJobRuns returns the scheduled times of the Jobs of a CronJob that are
+// still around. The creation time is used for Jobs without the scheduled time.
+func CronJobRuns(ctx context.Context, kc client.Reader, cj *batchv1.CronJob) ([]time.Time, error) {
+	var jobs batchv1.JobList
+	if err := kc.List(ctx, &jobs, client.InNamespace(cj.Namespace)); err != nil {
+		return nil, err
+	}
+	var runs []time.Time
+	for _, job := range jobs.Items {
+		if !ownedBy(job.OwnerReferences, cj.UID) {
+			continue
+		}
+		t := job.CreationTimestamp.Time
+		if scheduled, err := time.Parse(time.RFC3339, job.Annotations[annotationCronJobScheduledTimestamp]); err == nil {
+			t = scheduled
+		}
+		runs = append(runs, t)
+	}
+	return runs, nil
+}
+
+// CheckCronJob compares the CronJob of a BackupConfiguration with it. The
+// CronJob is nil if it does not exist.
+func CheckCronJob(cfg *stashv1beta1.BackupConfiguration, cj *batchv1.CronJob, now time.Time) uisrv.BackupCronJobStatus {
+	var status uisrv.BackupCronJobStatus
+	if cj == nil {
+		status.Warnings = append(status.Warnings, "no CronJob exists for the BackupConfiguration")
+		return status
+	}
+
+	status.Exists = true
+	status.Name = cj.Name
+	status.Schedule = cj.Spec.Schedule
+	status.Suspended = cj.Spec.Suspend != nil && *cj.Spec.Suspend
+	status.LastScheduleTime = cj.Status.LastScheduleTime
+	status.LastSuccessfulTime = cj.Status.LastSuccessfulTime
+	for _, job := range cj.Status.Active {
+		status.ActiveJobs = append(status.ActiveJobs, job.Name)
+	}
+
+	status.ScheduleMatches = cj.Spec.Schedule == cfg.Spec.Schedule
+	if !status.ScheduleMatches {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("the CronJob schedule %q differs from the BackupConfiguration schedule %q", cj.Spec.Schedule, cfg.Spec.Schedule))
+	}
+	status.SuspendMatches = status.Suspended == cfg.Spec.Paused
+	switch {
+	case status.Suspended && !cfg.Spec.Paused:
+		status.Warnings = append(status.Warnings, "the CronJob is suspended, but the BackupConfiguration is not paused")
+	case !status.Suspended && cfg.Spec.Paused:
+		status.Warnings = append(status.Warnings, "the CronJob is not suspended, but the BackupConfiguration is paused")
+	}
+
+	if !status.Suspended {
+		if sched, err := ParseSchedule(cj.Spec.Schedule); err == nil {
+			ref := cj.CreationTimestamp.Time
+			if last := cj.Status.LastScheduleTime; last != nil && last.After(ref) {
+				ref = last.Time
+			}
+			if next := sched.Next(ref); !next.IsZero() && now.Sub(next) > cronJobFireGrace {
+				status.Warnings = append(status.Warnings, fmt.Sprintf("the CronJob did not fire at %s", next.UTC().Format(time.RFC3339)))
+			}
+		}
+	}
+	if last := cj.Status.LastScheduleTime; last != nil && len(cj.Status.Active) == 0 &&
+		(cj.Status.LastSuccessfulTime == nil || cj.Status.LastSuccessfulTime.Before(last)) {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("the Job scheduled at %s did not succeed", last.UTC().Format(time.RFC3339)))
+	}
+	return status
+}
+
+// cronJobCondition summarizes the health of the CronJob for the overview
+func cronJobCondition(status uisrv.BackupCronJobStatus) kmapi.Condition {
+	c := kmapi.Condition{
+		Type:   uisrv.BackupCronJobHealthyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: uisrv.CronJobReasonHealthy,
+	}
+	if len(status.Warnings) > 0 {
+		c.Status = metav1.ConditionFalse
+		c.Reason = uisrv.CronJobReasonDrift
+		c.Message = strings.Join(status.Warnings, "; ")
+	}
+	if !status.Exists {
+		c.Reason = uisrv.CronJobReasonMissing
+	}
+	return c
+}
+
+func ownedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
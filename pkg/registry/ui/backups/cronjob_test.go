@@ -0,0 +1,106 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckCronJob(t *testing.T) {
+	now := time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)
+	created := metav1.NewTime(now.Add(-24 * time.Hour))
+	cfg := &stashv1beta1.BackupConfiguration{
+		Spec: stashv1beta1.BackupConfigurationSpec{Schedule: "0 * * * *"},
+	}
+	suspended := true
+
+	cases := []struct {
+		name     string
+		cj       *batchv1.CronJob
+		warnings []string
+	}{
+		{"missing", nil, []string{"no CronJob exists"}},
+		{
+			"healthy",
+			&batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+				Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+				Status: batchv1.CronJobStatus{
+					LastScheduleTime:   &metav1.Time{Time: now.Add(-30 * time.Minute)},
+					LastSuccessfulTime: &metav1.Time{Time: now.Add(-25 * time.Minute)},
+				},
+			},
+			nil,
+		},
+		{
+			"running",
+			&batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+				Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+				Status: batchv1.CronJobStatus{
+					Active:           []core.ObjectReference{{Name: "job-1"}},
+					LastScheduleTime: &metav1.Time{Time: now.Add(-30 * time.Minute)},
+				},
+			},
+			nil,
+		},
+		{
+			"drift",
+			&batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+				Spec:       batchv1.CronJobSpec{Schedule: "0 1 * * *", Suspend: &suspended},
+				Status: batchv1.CronJobStatus{
+					LastScheduleTime:   &metav1.Time{Time: now.Add(-3 * time.Hour)},
+					LastSuccessfulTime: &metav1.Time{Time: now.Add(-4 * time.Hour)},
+				},
+			},
+			[]string{"schedule", "suspended", "did not succeed"},
+		},
+		{
+			"stopped firing",
+			&batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+				Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+				Status: batchv1.CronJobStatus{
+					LastScheduleTime:   &metav1.Time{Time: now.Add(-150 * time.Minute)},
+					LastSuccessfulTime: &metav1.Time{Time: now.Add(-145 * time.Minute)},
+				},
+			},
+			[]string{"did not fire at 2024-05-15T09:00:00Z"},
+		},
+	}
+	for _, c := range cases {
+		status := CheckCronJob(cfg, c.cj, now)
+		if len(status.Warnings) != len(c.warnings) {
+			t.Errorf("%s: warnings = %q, expected %q", c.name, status.Warnings, c.warnings)
+			continue
+		}
+		for i := range c.warnings {
+			if !strings.Contains(status.Warnings[i], c.warnings[i]) {
+				t.Errorf("%s: warning %q does not mention %q", c.name, status.Warnings[i], c.warnings[i])
+			}
+		}
+	}
+}
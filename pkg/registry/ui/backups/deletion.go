@@ -0,0 +1,250 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackupDeletionStorage implements the deletion subresource of BackupOverview
+type BackupDeletionStorage struct {
+	kc client.Client
+	a  authorizer.Authorizer
+}
+
+var (
+	_ rest.Storage                  = &BackupDeletionStorage{}
+	_ rest.NamedCreater             = &BackupDeletionStorage{}
+	_ rest.GroupVersionKindProvider = &BackupDeletionStorage{}
+)
+
+func NewBackupDeletionStorage(kc client.Client, a authorizer.Authorizer) *BackupDeletionStorage {
+	return &BackupDeletionStorage{
+		kc: kc,
+		a:  a,
+	}
+}
+
+func (r *BackupDeletionStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupDeletion)
+}
+
+func (r *BackupDeletionStorage) New() runtime.Object {
+	return &uisrv.BackupDeletion{}
+}
+
+func (r *BackupDeletionStorage) Destroy() {}
+
+func (r *BackupDeletionStorage) Create(ctx context.Context, name string, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	u, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BackupDeletion)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupDeletion, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	// authorized before the Get, so that the existence is not disclosed
+	cfgGR := schema.GroupResource{Group: stashapi.GroupName, Resource: stashv1beta1.ResourcePluralBackupConfiguration}
+	if err := shared.Authorize(ctx, r.a, u, "delete", ns, cfgGR, name); err != nil {
+		return nil, err
+	}
+	backupConfig := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, backupConfig); err != nil {
+		return nil, err
+	}
+
+	opts := &client.DeleteOptions{}
+	if options != nil {
+		opts.DryRun = options.DryRun
+	}
+	status, err := deleteBackupConfiguration(ctx, r.kc, r.a, u, backupConfig, in.Spec, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := in.DeepCopy()
+	result.Name = name
+	result.Namespace = ns
+	result.CreationTimestamp = metav1.Now()
+	result.Status = *status
+	return result, nil
+}
+
+const (
+	// QueryRepositoryPolicy and QueryConfirm are the query parameters of a
+	// DELETE on a BackupOverview that match the spec of a BackupDeletion
+	QueryRepositoryPolicy = "repositoryPolicy"
+	QueryConfirm          = "confirm"
+)
+
+type deletionSpecKey struct{}
+
+// WithDeletionQuery passes the query parameters of a DELETE on a BackupOverview
+// to its storage, which only receives the DeleteOptions of the request.
+func WithDeletionQuery(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, ok := apirequest.RequestInfoFrom(req.Context())
+		if ok && info.IsResourceRequest && info.Verb == "delete" && info.APIGroup == ui.GroupName &&
+			info.Resource == uiapi.ResourceBackupOverviews && info.Subresource == "" {
+			q := req.URL.Query()
+			spec := uisrv.BackupDeletionSpec{
+				RepositoryPolicy: uisrv.RepositoryDeletionPolicy(q.Get(QueryRepositoryPolicy)),
+				Confirm:          q.Get(QueryConfirm),
+			}
+			req = req.WithContext(withDeletionSpec(req.Context(), spec))
+		}
+		h.ServeHTTP(w, req)
+	})
+}
+
+func withDeletionSpec(ctx context.Context, spec uisrv.BackupDeletionSpec) context.Context {
+	return context.WithValue(ctx, deletionSpecKey{}, spec)
+}
+
+// deletionSpecFrom returns the deletion spec of a DELETE request, which keeps
+// the Repository unless asked otherwise
+func deletionSpecFrom(ctx context.Context) uisrv.BackupDeletionSpec {
+	spec, _ := ctx.Value(deletionSpecKey{}).(uisrv.BackupDeletionSpec)
+	return spec
+}
+
+// deleteBackupConfiguration deletes a BackupConfiguration and handles its
+// Repository as requested. A Repository is only deleted if no other invoker
+// uses it and its name was confirmed. The caller checks that u may delete cfg.
+func deleteBackupConfiguration(ctx context.Context, kc client.Client, a authorizer.Authorizer, u user.Info, cfg *stashv1beta1.BackupConfiguration, spec uisrv.BackupDeletionSpec, opts *client.DeleteOptions) (*uisrv.BackupDeletionStatus, error) {
+	repoGR := schema.GroupResource{Group: stashapi.GroupName, Resource: stashv1alpha1.ResourcePluralRepository}
+	gk := uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupDeletion).GroupKind()
+
+	policy := spec.RepositoryPolicy
+	if policy == "" {
+		policy = uisrv.RepositoryDeletionPolicyKeep
+	}
+	specPath := field.NewPath("spec")
+	switch policy {
+	case uisrv.RepositoryDeletionPolicyKeep, uisrv.RepositoryDeletionPolicyDelete, uisrv.RepositoryDeletionPolicyWipeOut:
+	default:
+		return nil, apierrors.NewInvalid(gk, cfg.Name, field.ErrorList{field.NotSupported(specPath.Child("repositoryPolicy"), policy, []string{
+			string(uisrv.RepositoryDeletionPolicyKeep), string(uisrv.RepositoryDeletionPolicyDelete), string(uisrv.RepositoryDeletionPolicyWipeOut),
+		})})
+	}
+
+	repoKey := repositoryKey(cfg)
+	status := &uisrv.BackupDeletionStatus{
+		BackupConfiguration: cfg.Name,
+		Repository:          repoKey.Name,
+	}
+
+	var repo *stashv1alpha1.Repository
+	if policy != uisrv.RepositoryDeletionPolicyKeep {
+		if spec.Confirm != repoKey.Name {
+			return nil, apierrors.NewInvalid(gk, cfg.Name, field.ErrorList{field.Invalid(specPath.Child("confirm"), spec.Confirm, "must match the name of the Repository")})
+		}
+		if err := shared.Authorize(ctx, a, u, "delete", repoKey.Namespace, repoGR, repoKey.Name); err != nil {
+			return nil, err
+		}
+		if policy == uisrv.RepositoryDeletionPolicyWipeOut {
+			if err := shared.Authorize(ctx, a, u, "patch", repoKey.Namespace, repoGR, repoKey.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		repo = &stashv1alpha1.Repository{}
+		if err := kc.Get(ctx, repoKey, repo); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			repo = nil
+		}
+		if repo != nil {
+			if others := otherInvokers(repo, cfg); len(others) > 0 {
+				return nil, apierrors.NewConflict(repoGR, repo.Name, fmt.Errorf("still used by %s", strings.Join(others, ", ")))
+			}
+		}
+	}
+
+	if err := kc.Delete(ctx, cfg, opts); err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return status, nil
+	}
+
+	if policy == uisrv.RepositoryDeletionPolicyWipeOut && !repo.Spec.WipeOut {
+		orig := repo.DeepCopy()
+		repo.Spec.WipeOut = true
+		if err := kc.Patch(ctx, repo, client.MergeFrom(orig), &client.PatchOptions{DryRun: opts.DryRun}); err != nil {
+			return nil, fmt.Errorf("deleted BackupConfiguration %s/%s but failed to wipe out Repository %s, reason: %w", cfg.Namespace, cfg.Name, repo.Name, err)
+		}
+	}
+	if err := kc.Delete(ctx, repo, &client.DeleteOptions{DryRun: opts.DryRun}); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("deleted BackupConfiguration %s/%s but failed to delete Repository %s, reason: %w", cfg.Namespace, cfg.Name, repo.Name, err)
+	}
+	status.RepositoryDeleted = true
+	status.WipedOut = repo.Spec.WipeOut
+	return status, nil
+}
+
+// otherInvokers lists the invokers other than cfg that reference repo
+func otherInvokers(repo *stashv1alpha1.Repository, cfg *stashv1beta1.BackupConfiguration) []string {
+	var others []string
+	for _, ref := range repo.Status.References {
+		ns := ref.Namespace
+		if ns == "" {
+			ns = repo.Namespace
+		}
+		if ref.Kind == stashv1beta1.ResourceKindBackupConfiguration && ns == cfg.Namespace && ref.Name == cfg.Name {
+			continue
+		}
+		others = append(others, fmt.Sprintf("%s %s/%s", ref.Kind, ns, ref.Name))
+	}
+	return others
+}
@@ -0,0 +1,166 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newDeletionObjects(refs ...kmapi.TypedObjectReference) (*stashv1beta1.BackupConfiguration, *stashv1alpha1.Repository) {
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "demo"},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			Repository: kmapi.ObjectReference{Name: "app-repo"},
+			Schedule:   "0 * * * *",
+		},
+	}
+	repo := &stashv1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-repo", Namespace: "demo"},
+		Status:     stashv1alpha1.RepositoryStatus{References: refs},
+	}
+	return cfg, repo
+}
+
+func TestOtherInvokers(t *testing.T) {
+	cfg, repo := newDeletionObjects(
+		kmapi.TypedObjectReference{Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: "app"},
+		kmapi.TypedObjectReference{Kind: stashv1beta1.ResourceKindBackupConfiguration, Namespace: "other", Name: "app"},
+		kmapi.TypedObjectReference{Kind: stashv1beta1.ResourceKindBackupBatch, Namespace: "demo", Name: "batch"},
+	)
+	want := []string{"BackupConfiguration other/app", "BackupBatch demo/batch"}
+	if got := otherInvokers(repo, cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("otherInvokers() = %v, want %v", got, want)
+	}
+}
+
+func TestDeleteOverview(t *testing.T) {
+	self := kmapi.TypedObjectReference{Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: "app"}
+	batch := kmapi.TypedObjectReference{Kind: stashv1beta1.ResourceKindBackupBatch, Name: "batch"}
+	cases := []struct {
+		name     string
+		spec     uisrv.BackupDeletionSpec
+		refs     []kmapi.TypedObjectReference
+		wantErr  func(error) bool
+		repoGone bool
+	}{
+		{name: "keeps the Repository by default", refs: []kmapi.TypedObjectReference{self}},
+		{
+			name:     "deletes a confirmed Repository",
+			spec:     uisrv.BackupDeletionSpec{RepositoryPolicy: uisrv.RepositoryDeletionPolicyDelete, Confirm: "app-repo"},
+			refs:     []kmapi.TypedObjectReference{self},
+			repoGone: true,
+		},
+		{
+			name:     "wipes out a confirmed Repository",
+			spec:     uisrv.BackupDeletionSpec{RepositoryPolicy: uisrv.RepositoryDeletionPolicyWipeOut, Confirm: "app-repo"},
+			refs:     []kmapi.TypedObjectReference{self},
+			repoGone: true,
+		},
+		{
+			name:    "requires a confirmation",
+			spec:    uisrv.BackupDeletionSpec{RepositoryPolicy: uisrv.RepositoryDeletionPolicyDelete},
+			wantErr: apierrors.IsInvalid,
+		},
+		{
+			name:    "refuses to delete a Repository in use",
+			spec:    uisrv.BackupDeletionSpec{RepositoryPolicy: uisrv.RepositoryDeletionPolicyDelete, Confirm: "app-repo"},
+			refs:    []kmapi.TypedObjectReference{self, batch},
+			wantErr: apierrors.IsConflict,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg, repo := newDeletionObjects(c.refs...)
+			kc := testclient.New(t, cfg, repo)
+			r := NewBackupOverviewStorage(kc, shared.DirectClient(kc), denyResources())
+
+			ctx := withDeletionSpec(requestContext("demo"), c.spec)
+			_, _, err := r.Delete(ctx, "app", nil, &metav1.DeleteOptions{})
+			if c.wantErr != nil {
+				if !c.wantErr(err) {
+					t.Fatalf("unexpected error %v", err)
+				}
+				if err := kc.Get(ctx, client.ObjectKeyFromObject(cfg), cfg); err != nil {
+					t.Errorf("the BackupConfiguration must be kept, reason: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := kc.Get(ctx, client.ObjectKeyFromObject(cfg), cfg); !apierrors.IsNotFound(err) {
+				t.Errorf("the BackupConfiguration must be deleted, got %v", err)
+			}
+			err = kc.Get(ctx, client.ObjectKeyFromObject(repo), repo)
+			if gone := apierrors.IsNotFound(err); gone != c.repoGone {
+				t.Errorf("Repository deleted = %v, want %v", gone, c.repoGone)
+			}
+		})
+	}
+}
+
+func TestWithDeletionQuery(t *testing.T) {
+	var got uisrv.BackupDeletionSpec
+	h := WithDeletionQuery(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		got = deletionSpecFrom(req.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/apis/ui.stash.appscode.com/v1alpha1/namespaces/demo/backupoverviews/app?repositoryPolicy=WipeOut&confirm=app-repo", nil)
+	req = req.WithContext(apirequest.WithRequestInfo(req.Context(), &apirequest.RequestInfo{
+		IsResourceRequest: true,
+		Verb:              "delete",
+		APIGroup:          ui.GroupName,
+		Resource:          "backupoverviews",
+		Namespace:         "demo",
+		Name:              "app",
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	want := uisrv.BackupDeletionSpec{RepositoryPolicy: uisrv.RepositoryDeletionPolicyWipeOut, Confirm: "app-repo"}
+	if got != want {
+		t.Errorf("deletion spec %+v, want %+v", got, want)
+	}
+}
+
+func TestDeleteDoesNotDiscloseExistence(t *testing.T) {
+	kc := testclient.New(t)
+	a := denyResources("delete/" + stashv1beta1.ResourcePluralBackupConfiguration)
+	ctx := requestContext("demo")
+
+	if _, _, err := NewBackupOverviewStorage(kc, shared.DirectClient(kc), a).Delete(ctx, "missing", nil, &metav1.DeleteOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("overview: expected forbidden for a missing configuration, got %v", err)
+	}
+	if _, err := NewBackupDeletionStorage(kc, a).Create(ctx, "missing", &uisrv.BackupDeletion{}, nil, &metav1.CreateOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("deletion: expected forbidden for a missing configuration, got %v", err)
+	}
+}
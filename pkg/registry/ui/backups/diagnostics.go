@@ -0,0 +1,379 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultDiagnosticsTailLines = 50
+	maxDiagnosticsTailLines     = 500
+	// maxLogBytes bounds the log tail of a container
+	maxLogBytes = 64 * 1024
+)
+
+// Labels set by the Job controller on the Pods of a Job. The legacy one is
+// used by clusters older than Kubernetes 1.27.
+const (
+	labelJobControllerUID       = "batch.kubernetes.io/controller-uid"
+	labelLegacyJobControllerUID = "controller-uid"
+)
+
+// Reasons of a waiting container that can't pull its image
+var imagePullReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// BackupDiagnosticsStorage implements the diagnostics subresource of BackupOverview
+type BackupDiagnosticsStorage struct {
+	kc client.Client
+	// apiReader lists the Jobs, which are not cached. Pods are never cached.
+	apiReader client.Reader
+	// kubeClient reads the container logs
+	kubeClient kubernetes.Interface
+	a          authorizer.Authorizer
+	gr         schema.GroupResource
+}
+
+var (
+	_ rest.Storage                  = &BackupDiagnosticsStorage{}
+	_ rest.Getter                   = &BackupDiagnosticsStorage{}
+	_ rest.NamedCreater             = &BackupDiagnosticsStorage{}
+	_ rest.GroupVersionKindProvider = &BackupDiagnosticsStorage{}
+)
+
+func NewBackupDiagnosticsStorage(kc client.Client, apiReader client.Reader, kubeClient kubernetes.Interface, a authorizer.Authorizer) *BackupDiagnosticsStorage {
+	return &BackupDiagnosticsStorage{
+		kc:         kc,
+		apiReader:  apiReader,
+		kubeClient: kubeClient,
+		a:          a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupConfiguration,
+		},
+	}
+}
+
+func (r *BackupDiagnosticsStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupDiagnostics)
+}
+
+func (r *BackupDiagnosticsStorage) New() runtime.Object {
+	return &uisrv.BackupDiagnostics{}
+}
+
+func (r *BackupDiagnosticsStorage) Destroy() {}
+
+// Get diagnoses the default BackupSession
+func (r *BackupDiagnosticsStorage) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	return r.diagnose(ctx, name, &uisrv.BackupDiagnostics{})
+}
+
+// Create diagnoses the BackupSession given in the spec
+func (r *BackupDiagnosticsStorage) Create(ctx context.Context, name string, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	in, ok := obj.(*uisrv.BackupDiagnostics)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupDiagnostics, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	return r.diagnose(ctx, name, in)
+}
+
+func (r *BackupDiagnosticsStorage) diagnose(ctx context.Context, name string, in *uisrv.BackupDiagnostics) (*uisrv.BackupDiagnostics, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	for _, attrs := range []authorizer.AttributesRecord{
+		{User: user, Verb: "get", Namespace: ns, APIGroup: r.gr.Group, Resource: r.gr.Resource, Name: name, ResourceRequest: true},
+		{User: user, Verb: "get", Namespace: ns, APIGroup: core.GroupName, Resource: "pods", Subresource: "log", ResourceRequest: true},
+	} {
+		decision, why, err := r.a.Authorize(ctx, attrs)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		if decision != authorizer.DecisionAllow {
+			return nil, apierrors.NewForbidden(schema.GroupResource{Group: attrs.APIGroup, Resource: attrs.Resource}, attrs.Name, errors.New(why))
+		}
+	}
+
+	tailLines := int64(defaultDiagnosticsTailLines)
+	if in.Spec.TailLines != nil {
+		if *in.Spec.TailLines <= 0 || *in.Spec.TailLines > maxDiagnosticsTailLines {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("tailLines must be between 1 and %d", maxDiagnosticsTailLines))
+		}
+		tailLines = *in.Spec.TailLines
+	}
+
+	cfg := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, cfg); err != nil {
+		return nil, err
+	}
+	session, err := r.session(ctx, cfg, in.Spec.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	status := uisrv.BackupDiagnosticsStatus{
+		Session:      session.Name,
+		SessionPhase: session.Status.Phase,
+	}
+	status.Errors = slices.AppendSeq(status.Errors, maps.Values(sessionFailures(session)))
+	sort.Strings(status.Errors)
+
+	var jobs batchv1.JobList
+	if err := r.apiReader.List(ctx, &jobs, client.InNamespace(ns)); err != nil {
+		return nil, err
+	}
+	for _, job := range jobs.Items {
+		if !ownedBy(job.OwnerReferences, session.UID) {
+			continue
+		}
+		jd, problems := jobDiagnostics(&job)
+		status.Jobs = append(status.Jobs, jd)
+		status.Problems = append(status.Problems, problems...)
+
+		pods, err := r.jobPods(ctx, &job)
+		if err != nil {
+			return nil, err
+		}
+		for i := range pods {
+			pd, problems := podDiagnostics(&pods[i])
+			pd.Job = job.Name
+			r.tailLogs(ctx, &pods[i], &pd, tailLines)
+			status.Pods = append(status.Pods, pd)
+			status.Problems = append(status.Problems, problems...)
+		}
+	}
+	if len(status.Jobs) == 0 {
+		status.Problems = append(status.Problems, "no Jobs found for the BackupSession, its target may be backed up by a sidecar")
+	}
+
+	result := in.DeepCopy()
+	result.Name = name
+	result.Namespace = ns
+	result.CreationTimestamp = session.CreationTimestamp
+	result.Status = status
+	return result, nil
+}
+
+// session returns the named BackupSession of a configuration, or by default
+// the latest failed one or the latest one
+func (r *BackupDiagnosticsStorage) session(ctx context.Context, cfg *stashv1beta1.BackupConfiguration, name string) (*stashv1beta1.BackupSession, error) {
+	sessionGR := schema.GroupResource{Group: stashapi.GroupName, Resource: stashv1beta1.ResourcePluralBackupSession}
+	if name != "" {
+		session := &stashv1beta1.BackupSession{}
+		if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: cfg.Namespace}, session); err != nil {
+			return nil, err
+		}
+		if session.Spec.Invoker.Kind != stashv1beta1.ResourceKindBackupConfiguration || session.Spec.Invoker.Name != cfg.Name {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("BackupSession %s was not created for BackupConfiguration %s", name, cfg.Name))
+		}
+		return session, nil
+	}
+
+	var sessions stashv1beta1.BackupSessionList
+	if err := r.kc.List(ctx, &sessions, client.InNamespace(cfg.Namespace)); err != nil {
+		return nil, err
+	}
+	var failed []stashv1beta1.BackupSession
+	for _, s := range sessions.Items {
+		if s.Status.Phase == stashv1beta1.BackupSessionFailed {
+			failed = append(failed, s)
+		}
+	}
+	if s := LatestBackupSession(failed, stashv1beta1.ResourceKindBackupConfiguration, cfg.Name); s != nil {
+		return s, nil
+	}
+	if s := LatestBackupSession(sessions.Items, stashv1beta1.ResourceKindBackupConfiguration, cfg.Name); s != nil {
+		return s, nil
+	}
+	return nil, apierrors.NewNotFound(sessionGR, "")
+}
+
+// jobPods returns the Pods of a Job. Pods are excluded from the cache, so they are read live.
+func (r *BackupDiagnosticsStorage) jobPods(ctx context.Context, job *batchv1.Job) ([]core.Pod, error) {
+	for _, label := range []string{labelJobControllerUID, labelLegacyJobControllerUID} {
+		var pods core.PodList
+		if err := r.kc.List(ctx, &pods, client.InNamespace(job.Namespace), client.MatchingLabels{label: string(job.UID)}); err != nil {
+			return nil, err
+		}
+		if len(pods.Items) > 0 {
+			sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].CreationTimestamp.Before(&pods.Items[j].CreationTimestamp) })
+			return pods.Items, nil
+		}
+	}
+	return nil, nil
+}
+
+// tailLogs adds the log tail of the containers that ran. The previous log is
+// read for a container waiting to be restarted.
+func (r *BackupDiagnosticsStorage) tailLogs(ctx context.Context, pod *core.Pod, pd *uisrv.PodDiagnostics, tailLines int64) {
+	limitBytes := int64(maxLogBytes)
+	for i := range pd.Containers {
+		c := &pd.Containers[i]
+		if c.State == "" || (c.State == containerStateWaiting && c.LastExitCode == nil) {
+			continue
+		}
+		opts := &core.PodLogOptions{
+			Container:  c.Name,
+			TailLines:  &tailLines,
+			LimitBytes: &limitBytes,
+			Previous:   c.State == containerStateWaiting,
+		}
+		data, err := r.kubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+		if err != nil {
+			c.LogError = err.Error()
+			continue
+		}
+		c.LogTail = string(data)
+	}
+}
+
+// States of a container in ContainerDiagnostics
+const (
+	containerStateWaiting    = "Waiting"
+	containerStateRunning    = "Running"
+	containerStateTerminated = "Terminated"
+)
+
+// jobDiagnostics summarizes a Job along with the problems it shows
+func jobDiagnostics(job *batchv1.Job) (uisrv.JobDiagnostics, []string) {
+	jd := uisrv.JobDiagnostics{
+		Name:      job.Name,
+		Active:    job.Status.Active,
+		Succeeded: job.Status.Succeeded,
+		Failed:    job.Status.Failed,
+	}
+	var problems []string
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == core.ConditionTrue {
+			jd.FailureReason = c.Reason
+			jd.FailureMessage = c.Message
+			problems = append(problems, fmt.Sprintf("Job %s failed: %s: %s", job.Name, c.Reason, c.Message))
+		}
+	}
+	return jd, problems
+}
+
+// podDiagnostics summarizes a Pod and its containers along with the problems they show
+func podDiagnostics(pod *core.Pod) (uisrv.PodDiagnostics, []string) {
+	pd := uisrv.PodDiagnostics{
+		Name:     pod.Name,
+		Phase:    pod.Status.Phase,
+		NodeName: pod.Spec.NodeName,
+	}
+	var problems []string
+	for _, c := range pod.Status.Conditions {
+		if c.Type == core.PodScheduled && c.Status == core.ConditionFalse {
+			pd.SchedulingFailure = c.Message
+			if pd.SchedulingFailure == "" {
+				pd.SchedulingFailure = c.Reason
+			}
+			problems = append(problems, fmt.Sprintf("Pod %s can't be scheduled: %s", pod.Name, pd.SchedulingFailure))
+		}
+	}
+
+	add := func(statuses []core.ContainerStatus, init bool) {
+		for _, cs := range statuses {
+			cd := containerDiagnostics(cs)
+			cd.Init = init
+			pd.Restarts += cs.RestartCount
+			pd.Containers = append(pd.Containers, cd)
+
+			switch {
+			case cd.State == containerStateWaiting && imagePullReasons[cd.Reason]:
+				problems = append(problems, fmt.Sprintf("container %s of Pod %s can't pull image %s: %s", cs.Name, pod.Name, cs.Image, cd.Reason))
+			case cd.State == containerStateTerminated && cd.Reason == "OOMKilled":
+				problems = append(problems, fmt.Sprintf("container %s of Pod %s was OOMKilled", cs.Name, pod.Name))
+			case cd.State == containerStateTerminated && cd.ExitCode != nil && *cd.ExitCode != 0:
+				problems = append(problems, fmt.Sprintf("container %s of Pod %s exited with code %d (%s)", cs.Name, pod.Name, *cd.ExitCode, cd.Reason))
+			}
+			if cs.RestartCount > 0 {
+				msg := fmt.Sprintf("container %s of Pod %s restarted %d times", cs.Name, pod.Name, cs.RestartCount)
+				if cd.LastTerminationReason != "" {
+					msg += fmt.Sprintf(", last terminated with %s", cd.LastTerminationReason)
+				}
+				problems = append(problems, msg)
+			}
+		}
+	}
+	add(pod.Status.InitContainerStatuses, true)
+	add(pod.Status.ContainerStatuses, false)
+	return pd, problems
+}
+
+func containerDiagnostics(cs core.ContainerStatus) uisrv.ContainerDiagnostics {
+	cd := uisrv.ContainerDiagnostics{
+		Name:         cs.Name,
+		RestartCount: cs.RestartCount,
+	}
+	switch {
+	case cs.State.Waiting != nil:
+		cd.State = containerStateWaiting
+		cd.Reason = cs.State.Waiting.Reason
+		cd.Message = cs.State.Waiting.Message
+	case cs.State.Running != nil:
+		cd.State = containerStateRunning
+	case cs.State.Terminated != nil:
+		cd.State = containerStateTerminated
+		cd.Reason = cs.State.Terminated.Reason
+		cd.Message = cs.State.Terminated.Message
+		cd.ExitCode = &cs.State.Terminated.ExitCode
+	}
+	if t := cs.LastTerminationState.Terminated; t != nil {
+		cd.LastTerminationReason = t.Reason
+		cd.LastExitCode = &t.ExitCode
+	}
+	return cd
+}
@@ -0,0 +1,90 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"reflect"
+	"testing"
+
+	core "k8s.io/api/core/v1"
+)
+
+func TestPodDiagnostics(t *testing.T) {
+	pod := &core.Pod{
+		Status: core.PodStatus{
+			Phase: core.PodFailed,
+			InitContainerStatuses: []core.ContainerStatus{{
+				Name:  "init",
+				Image: "stash:bad",
+				State: core.ContainerState{Waiting: &core.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			}},
+			ContainerStatuses: []core.ContainerStatus{
+				{
+					Name:                 "backup",
+					RestartCount:         2,
+					State:                core.ContainerState{Terminated: &core.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+					LastTerminationState: core.ContainerState{Terminated: &core.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+				},
+				{
+					Name:  "sidecar",
+					State: core.ContainerState{Terminated: &core.ContainerStateTerminated{Reason: "Error", ExitCode: 2}},
+				},
+			},
+		},
+	}
+	pod.Name = "backup-1"
+
+	pd, problems := podDiagnostics(pod)
+	if pd.Restarts != 2 || len(pd.Containers) != 3 || !pd.Containers[0].Init {
+		t.Fatalf("unexpected diagnostics %+v", pd)
+	}
+	if c := pd.Containers[1]; c.State != containerStateTerminated || *c.ExitCode != 137 || c.LastTerminationReason != "Error" || *c.LastExitCode != 1 {
+		t.Errorf("unexpected container diagnostics %+v", c)
+	}
+	want := []string{
+		"container init of Pod backup-1 can't pull image stash:bad: ImagePullBackOff",
+		"container backup of Pod backup-1 was OOMKilled",
+		"container backup of Pod backup-1 restarted 2 times, last terminated with Error",
+		"container sidecar of Pod backup-1 exited with code 2 (Error)",
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("problems = %q, want %q", problems, want)
+	}
+}
+
+func TestPodDiagnosticsUnschedulable(t *testing.T) {
+	pod := &core.Pod{
+		Status: core.PodStatus{
+			Phase: core.PodPending,
+			Conditions: []core.PodCondition{{
+				Type:    core.PodScheduled,
+				Status:  core.ConditionFalse,
+				Reason:  "Unschedulable",
+				Message: "0/3 nodes are available: 3 Insufficient memory.",
+			}},
+		},
+	}
+	pod.Name = "backup-1"
+
+	pd, problems := podDiagnostics(pod)
+	if pd.SchedulingFailure != "0/3 nodes are available: 3 Insufficient memory." {
+		t.Errorf("unexpected scheduling failure %q", pd.SchedulingFailure)
+	}
+	if len(problems) != 1 {
+		t.Errorf("unexpected problems %q", problems)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultFailureReportWindow = 7 * 24 * time.Hour
+
+type BackupFailureReportStorage struct {
+	kc        client.Client
+	a         authorizer.Authorizer
+	gr        schema.GroupResource
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &BackupFailureReportStorage{}
+	_ rest.Scoper                   = &BackupFailureReportStorage{}
+	_ rest.Storage                  = &BackupFailureReportStorage{}
+	_ rest.Creater                  = &BackupFailureReportStorage{}
+	_ rest.SingularNameProvider     = &BackupFailureReportStorage{}
+)
+
+func NewBackupFailureReportStorage(kc client.Client, a authorizer.Authorizer) *BackupFailureReportStorage {
+	return &BackupFailureReportStorage{
+		kc: kc,
+		a:  a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupSession,
+		},
+		convertor: rest.NewDefaultTableConvertor(schema.GroupResource{
+			Group:    ui.GroupName,
+			Resource: uisrv.ResourceBackupFailureReports,
+		}),
+	}
+}
+
+func (r *BackupFailureReportStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupFailureReport)
+}
+
+func (r *BackupFailureReportStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindBackupFailureReport)
+}
+
+func (r *BackupFailureReportStorage) NamespaceScoped() bool {
+	return false
+}
+
+func (r *BackupFailureReportStorage) New() runtime.Object {
+	return &uisrv.BackupFailureReport{}
+}
+
+func (r *BackupFailureReportStorage) Destroy() {}
+
+func (r *BackupFailureReportStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BackupFailureReport)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupFailureReport, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            user,
+		Verb:            "list",
+		Namespace:       in.Spec.Namespace,
+		APIGroup:        r.gr.Group,
+		Resource:        r.gr.Resource,
+		ResourceRequest: true,
+	}
+	decision, why, err := r.a.Authorize(ctx, attrs)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return nil, apierrors.NewForbidden(r.gr, "", errors.New(why))
+	}
+
+	window := defaultFailureReportWindow
+	if in.Spec.Window != nil {
+		if in.Spec.Window.Duration <= 0 {
+			return nil, apierrors.NewBadRequest("window must be positive")
+		}
+		window = in.Spec.Window.Duration
+	}
+	end := time.Now()
+	if in.Spec.End != nil {
+		end = in.Spec.End.Time
+	}
+
+	sessions := stashv1beta1.BackupSessionList{}
+	if err := r.kc.List(ctx, &sessions, client.InNamespace(in.Spec.Namespace)); err != nil {
+		return nil, err
+	}
+
+	result := in.DeepCopy()
+	result.CreationTimestamp = metav1.Now()
+	result.Status = analyzeFailures(sessions.Items, end, window)
+	return result, nil
+}
+
+func (r *BackupFailureReportStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
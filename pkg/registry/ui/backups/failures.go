@@ -0,0 +1,218 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+const (
+	failedReasonPrefix = "FailedTo"
+	maxErrorLength     = 160
+)
+
+var (
+	reQuoted = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	rePath   = regexp.MustCompile(`(/[\w.\-]+)+/?`)
+	reHex    = regexp.MustCompile(`\b[0-9a-f]{8,}\b`)
+	reNumber = regexp.MustCompile(`\b\d+(\.\d+)?\b`)
+	reSpace  = regexp.MustCompile(`\s+`)
+)
+
+type failureKey struct {
+	source uisrv.FailureSource
+	reason string
+}
+
+type failureGroup struct {
+	uisrv.FailureGroup
+	invokers map[kmapi.TypedObjectReference]struct{}
+}
+
+// analyzeFailures groups the failures of sessions created in [end-window, end)
+// by condition reason and by error text, and compares them with the
+// failures of the window before it.
+func analyzeFailures(sessions []stashv1beta1.BackupSession, end time.Time, window time.Duration) uisrv.BackupFailureReportStatus {
+	start := end.Add(-window)
+	prevStart := start.Add(-window)
+
+	status := uisrv.BackupFailureReportStatus{
+		Start: metav1.NewTime(start),
+		End:   metav1.NewTime(end),
+	}
+	groups := map[failureKey]*failureGroup{}
+
+	for _, s := range sessions {
+		created := s.CreationTimestamp.Time
+		if created.Before(prevStart) || !created.Before(end) {
+			continue
+		}
+		current := !created.Before(start)
+
+		failures := sessionFailures(&s)
+		if current {
+			status.TotalSessions++
+		}
+		if len(failures) > 0 || s.Status.Phase == stashv1beta1.BackupSessionFailed {
+			if current {
+				status.FailedSessions++
+			} else {
+				status.PreviousFailedSessions++
+			}
+		}
+
+		invoker := kmapi.TypedObjectReference{
+			APIGroup:  stashapi.GroupName,
+			Kind:      s.Spec.Invoker.Kind,
+			Namespace: s.Namespace,
+			Name:      s.Spec.Invoker.Name,
+		}
+		for key, sample := range failures {
+			g, ok := groups[key]
+			if !ok {
+				g = &failureGroup{
+					FailureGroup: uisrv.FailureGroup{
+						Source: key.source,
+						Reason: key.reason,
+					},
+					invokers: map[kmapi.TypedObjectReference]struct{}{},
+				}
+				groups[key] = g
+			}
+			if !current {
+				g.PreviousCount++
+				continue
+			}
+			g.Count++
+			if g.Sample == "" {
+				g.Sample = sample
+			}
+			g.invokers[invoker] = struct{}{}
+			if g.FirstOccurrence == nil || created.Before(g.FirstOccurrence.Time) {
+				g.FirstOccurrence = &metav1.Time{Time: created}
+			}
+			if g.LastOccurrence == nil || created.After(g.LastOccurrence.Time) {
+				g.LastOccurrence = &metav1.Time{Time: created}
+			}
+		}
+	}
+
+	for _, g := range groups {
+		for ref := range g.invokers {
+			g.AffectedConfigurations = append(g.AffectedConfigurations, ref)
+		}
+		sort.Slice(g.AffectedConfigurations, func(i, j int) bool {
+			x, y := g.AffectedConfigurations[i], g.AffectedConfigurations[j]
+			if x.Namespace != y.Namespace {
+				return x.Namespace < y.Namespace
+			}
+			if x.Kind != y.Kind {
+				return x.Kind < y.Kind
+			}
+			return x.Name < y.Name
+		})
+		g.Trend = failureTrend(g.Count, g.PreviousCount)
+		status.Groups = append(status.Groups, g.FailureGroup)
+	}
+	sort.Slice(status.Groups, func(i, j int) bool {
+		x, y := status.Groups[i], status.Groups[j]
+		if x.Count != y.Count {
+			return x.Count > y.Count
+		}
+		if x.Source != y.Source {
+			return x.Source < y.Source
+		}
+		return x.Reason < y.Reason
+	})
+	return status
+}
+
+// sessionFailures returns the failure groups of a session along with a
+// sample message for each of them. A session is counted once per group.
+func sessionFailures(s *stashv1beta1.BackupSession) map[failureKey]string {
+	failures := map[failureKey]string{}
+	addConditions := func(conditions []kmapi.Condition) {
+		for _, c := range conditions {
+			if strings.HasPrefix(c.Reason, failedReasonPrefix) {
+				key := failureKey{source: uisrv.FailureSourceCondition, reason: c.Reason}
+				if _, ok := failures[key]; !ok {
+					failures[key] = c.Message
+				}
+			}
+		}
+	}
+
+	addConditions(s.Status.Conditions)
+	for _, t := range s.Status.Targets {
+		addConditions(t.Conditions)
+		for _, stats := range t.Stats {
+			if stats.Error == "" {
+				continue
+			}
+			key := failureKey{source: uisrv.FailureSourceError, reason: normalizeError(stats.Error)}
+			if _, ok := failures[key]; !ok {
+				failures[key] = stats.Error
+			}
+		}
+	}
+	return failures
+}
+
+// normalizeError masks the parts of an error message that vary between
+// occurrences so that similar errors are clustered together.
+func normalizeError(msg string) string {
+	msg = strings.ToLower(msg)
+	msg = reQuoted.ReplaceAllString(msg, "<str>")
+	msg = rePath.ReplaceAllString(msg, "<path>")
+	msg = reHex.ReplaceAllString(msg, "<id>")
+	msg = reNumber.ReplaceAllString(msg, "<n>")
+	msg = strings.TrimSpace(reSpace.ReplaceAllString(msg, " "))
+	if len(msg) > maxErrorLength {
+		// cut on a rune boundary so that the message stays valid UTF-8
+		n := maxErrorLength
+		for n > 0 && !utf8.RuneStart(msg[n]) {
+			n--
+		}
+		msg = msg[:n]
+	}
+	return msg
+}
+
+func failureTrend(cur, prev int64) uisrv.FailureTrend {
+	switch {
+	case prev == 0:
+		return uisrv.FailureTrendNew
+	case cur == 0:
+		return uisrv.FailureTrendResolved
+	case cur > prev:
+		return uisrv.FailureTrendIncreasing
+	case cur < prev:
+		return uisrv.FailureTrendDecreasing
+	}
+	return uisrv.FailureTrendUnchanged
+}
@@ -0,0 +1,91 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+func TestNormalizeError(t *testing.T) {
+	x := normalizeError(`Fatal: unable to open repo at s3:s3.amazonaws.com/bucket-1/app: 403 "AccessDenied" after 3 retries`)
+	y := normalizeError(`fatal: unable to open repo at s3:s3.amazonaws.com/bucket-2/db: 403 "Forbidden"  after 5 retries`)
+	if x != y {
+		t.Errorf("expected errors to be clustered together, got %q and %q", x, y)
+	}
+
+	long := normalizeError(strings.Repeat("é", maxErrorLength))
+	if !utf8.ValidString(long) || len(long) > maxErrorLength {
+		t.Errorf("expected a valid message of at most %d bytes, got %q", maxErrorLength, long)
+	}
+}
+
+func TestAnalyzeFailures(t *testing.T) {
+	end := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+	session := func(name, cfg string, age time.Duration, reasons ...string) stashv1beta1.BackupSession {
+		s := stashv1beta1.BackupSession{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "demo",
+				CreationTimestamp: metav1.NewTime(end.Add(-age)),
+			},
+			Spec: stashv1beta1.BackupSessionSpec{
+				Invoker: stashv1beta1.BackupInvokerRef{Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: cfg},
+			},
+		}
+		for _, reason := range reasons {
+			s.Status.Phase = stashv1beta1.BackupSessionFailed
+			s.Status.Conditions = append(s.Status.Conditions, kmapi.Condition{Reason: reason})
+		}
+		return s
+	}
+	day := 24 * time.Hour
+	sessions := []stashv1beta1.BackupSession{
+		session("s1", "app", 1*day, stashv1beta1.FailedToCompleteWithinDeadline),
+		session("s2", "db", 2*day, stashv1beta1.FailedToCompleteWithinDeadline),
+		session("s3", "app", 3*day),
+		session("s4", "app", 8*day, stashv1beta1.FailedToCompleteWithinDeadline, stashv1beta1.FailedToApplyRetentionPolicy),
+		session("s5", "app", 20*day, stashv1beta1.FailedToExecutePreBackupHook),
+	}
+
+	status := analyzeFailures(sessions, end, 7*day)
+	if status.TotalSessions != 3 || status.FailedSessions != 2 || status.PreviousFailedSessions != 1 {
+		t.Fatalf("unexpected totals %d/%d/%d", status.TotalSessions, status.FailedSessions, status.PreviousFailedSessions)
+	}
+	if len(status.Groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(status.Groups))
+	}
+	g := status.Groups[0]
+	if g.Reason != stashv1beta1.FailedToCompleteWithinDeadline || g.Count != 2 || g.PreviousCount != 1 ||
+		g.Trend != uisrv.FailureTrendIncreasing || len(g.AffectedConfigurations) != 2 {
+		t.Errorf("unexpected group %+v", g)
+	}
+	if !g.FirstOccurrence.Equal(&sessions[1].CreationTimestamp) || !g.LastOccurrence.Equal(&sessions[0].CreationTimestamp) {
+		t.Errorf("unexpected occurrences %v - %v", g.FirstOccurrence, g.LastOccurrence)
+	}
+	if g := status.Groups[1]; g.Reason != stashv1beta1.FailedToApplyRetentionPolicy || g.Trend != uisrv.FailureTrendResolved {
+		t.Errorf("unexpected group %+v", g)
+	}
+}
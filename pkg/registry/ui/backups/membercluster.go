@@ -0,0 +1,154 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/clusters"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+// MemberClusterStorage reports the health of the clusters aggregated into
+// the ClusterBackupOverviews
+type MemberClusterStorage struct {
+	clusters  *clusters.Set
+	a         authorizer.Authorizer
+	gr        schema.GroupResource
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &MemberClusterStorage{}
+	_ rest.Scoper                   = &MemberClusterStorage{}
+	_ rest.Storage                  = &MemberClusterStorage{}
+	_ rest.Getter                   = &MemberClusterStorage{}
+	_ rest.Lister                   = &MemberClusterStorage{}
+	_ rest.SingularNameProvider     = &MemberClusterStorage{}
+)
+
+func NewMemberClusterStorage(set *clusters.Set, a authorizer.Authorizer) *MemberClusterStorage {
+	gr := schema.GroupResource{
+		Group:    ui.GroupName,
+		Resource: uisrv.ResourceMemberClusters,
+	}
+	return &MemberClusterStorage{
+		clusters:  set,
+		a:         a,
+		gr:        gr,
+		convertor: rest.NewDefaultTableConvertor(gr),
+	}
+}
+
+func (r *MemberClusterStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindMemberCluster)
+}
+
+func (r *MemberClusterStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindMemberCluster)
+}
+
+func (r *MemberClusterStorage) NamespaceScoped() bool {
+	return false
+}
+
+func (r *MemberClusterStorage) New() runtime.Object {
+	return &uisrv.MemberCluster{}
+}
+
+func (r *MemberClusterStorage) Destroy() {}
+
+func (r *MemberClusterStorage) NewList() runtime.Object {
+	return &uisrv.MemberClusterList{}
+}
+
+func (r *MemberClusterStorage) authorize(ctx context.Context, verb, name string) error {
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return apierrors.NewBadRequest("missing user info")
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            user,
+		Verb:            verb,
+		APIGroup:        r.gr.Group,
+		Resource:        r.gr.Resource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, why, err := r.a.Authorize(ctx, attrs)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return apierrors.NewForbidden(r.gr, name, errors.New(why))
+	}
+	return nil
+}
+
+func (r *MemberClusterStorage) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	if err := r.authorize(ctx, "get", name); err != nil {
+		return nil, err
+	}
+	m := r.clusters.Get(name)
+	if m == nil {
+		return nil, apierrors.NewNotFound(r.gr, name)
+	}
+	out := newMemberCluster(m)
+	return &out, nil
+}
+
+func (r *MemberClusterStorage) List(ctx context.Context, _ *internalversion.ListOptions) (runtime.Object, error) {
+	if err := r.authorize(ctx, "list", ""); err != nil {
+		return nil, err
+	}
+	result := &uisrv.MemberClusterList{}
+	for _, m := range r.clusters.Members() {
+		result.Items = append(result.Items, newMemberCluster(m))
+	}
+	return result, nil
+}
+
+func (r *MemberClusterStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
+
+func newMemberCluster(m *clusters.Member) uisrv.MemberCluster {
+	return uisrv.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   m.Name,
+			Labels: map[string]string{uisrv.LabelCluster: m.Name},
+		},
+		Spec: uisrv.MemberClusterSpec{
+			Local:   m.Local,
+			Context: m.Context,
+		},
+		Status: m.Status(),
+	}
+}
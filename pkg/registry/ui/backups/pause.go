@@ -0,0 +1,239 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type BackupPauseStorage struct {
+	kc client.Client
+	// uc patches the invokers as the requester
+	uc        shared.UserClient
+	a         authorizer.Authorizer
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &BackupPauseStorage{}
+	_ rest.Scoper                   = &BackupPauseStorage{}
+	_ rest.Storage                  = &BackupPauseStorage{}
+	_ rest.Creater                  = &BackupPauseStorage{}
+	_ rest.SingularNameProvider     = &BackupPauseStorage{}
+)
+
+func NewBackupPauseStorage(kc client.Client, uc shared.UserClient, a authorizer.Authorizer) *BackupPauseStorage {
+	return &BackupPauseStorage{
+		kc: kc,
+		uc: uc,
+		a:  a,
+		convertor: rest.NewDefaultTableConvertor(schema.GroupResource{
+			Group:    ui.GroupName,
+			Resource: uisrv.ResourceBackupPauses,
+		}),
+	}
+}
+
+func (r *BackupPauseStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupPause)
+}
+
+func (r *BackupPauseStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindBackupPause)
+}
+
+func (r *BackupPauseStorage) NamespaceScoped() bool {
+	return true
+}
+
+func (r *BackupPauseStorage) New() runtime.Object {
+	return &uisrv.BackupPause{}
+}
+
+func (r *BackupPauseStorage) Destroy() {}
+
+func (r *BackupPauseStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	u, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BackupPause)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupPause, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	invoker, gr, err := newInvoker(in.Spec.Invoker.Kind)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	if err := shared.Authorize(ctx, r.a, u, "patch", ns, gr, in.Spec.Invoker.Name); err != nil {
+		return nil, err
+	}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: in.Spec.Invoker.Name, Namespace: ns}, invoker); err != nil {
+		return nil, err
+	}
+
+	var dryRun []string
+	if options != nil {
+		dryRun = options.DryRun
+	}
+	uc, err := r.uc(u)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	changed, err := setPaused(ctx, uc, u, invoker, in.Spec.Paused, in.Spec.Reason, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	result := in.DeepCopy()
+	result.Namespace = ns
+	result.CreationTimestamp = metav1.Now()
+	result.Status = pauseStatus(invoker)
+	result.Status.Changed = changed
+	return result, nil
+}
+
+func (r *BackupPauseStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
+
+// newInvoker returns an empty backup invoker of the given kind along with its resource
+func newInvoker(kind string) (client.Object, schema.GroupResource, error) {
+	switch kind {
+	case stashv1beta1.ResourceKindBackupConfiguration:
+		return &stashv1beta1.BackupConfiguration{}, schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupConfiguration,
+		}, nil
+	case stashv1beta1.ResourceKindBackupBatch:
+		return &stashv1beta1.BackupBatch{}, schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupBatch,
+		}, nil
+	}
+	return nil, schema.GroupResource{}, fmt.Errorf("unsupported invoker kind %q", kind)
+}
+
+func invokerPaused(obj client.Object) *bool {
+	switch o := obj.(type) {
+	case *stashv1beta1.BackupConfiguration:
+		return &o.Spec.Paused
+	case *stashv1beta1.BackupBatch:
+		return &o.Spec.Paused
+	}
+	return nil
+}
+
+// setPaused pauses or resumes a backup invoker with kc, which is expected to
+// act as the user u. The caller checks that u is allowed to patch the invoker.
+// The user and the reason are recorded as annotations so that they can be
+// shown on the overview. Invokers that are already in the requested state
+// are left untouched.
+func setPaused(ctx context.Context, kc client.Client, u user.Info, obj client.Object, paused bool, reason string, dryRun []string) (bool, error) {
+	if invokerPaused(obj) == nil {
+		return false, apierrors.NewBadRequest(fmt.Sprintf("%T is not a backup invoker", obj))
+	}
+	orig := obj.DeepCopyObject().(client.Object)
+	if !markPaused(obj, paused, u.GetName(), reason) {
+		return false, nil
+	}
+
+	patch := client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})
+	if err := kc.Patch(ctx, obj, patch, &client.PatchOptions{DryRun: dryRun}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// markPaused sets the paused state of a backup invoker and records who
+// changed it. It returns false if the invoker is already in that state.
+func markPaused(obj client.Object, paused bool, by, reason string) bool {
+	p := invokerPaused(obj)
+	if p == nil || *p == paused {
+		return false
+	}
+
+	*p = paused
+	annotations := obj.GetAnnotations()
+	if paused {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[uisrv.AnnotationPausedBy] = by
+		annotations[uisrv.AnnotationPausedAt] = time.Now().UTC().Format(time.RFC3339)
+		if reason != "" {
+			annotations[uisrv.AnnotationPauseReason] = reason
+		} else {
+			delete(annotations, uisrv.AnnotationPauseReason)
+		}
+	} else {
+		delete(annotations, uisrv.AnnotationPausedBy)
+		delete(annotations, uisrv.AnnotationPausedAt)
+		delete(annotations, uisrv.AnnotationPauseReason)
+	}
+	obj.SetAnnotations(annotations)
+	return true
+}
+
+// pauseStatus reads the pause state of a backup invoker
+func pauseStatus(obj client.Object) uisrv.BackupPauseStatus {
+	var status uisrv.BackupPauseStatus
+	if p := invokerPaused(obj); p != nil {
+		status.Paused = *p
+	}
+	if !status.Paused {
+		return status
+	}
+	annotations := obj.GetAnnotations()
+	status.PausedBy = annotations[uisrv.AnnotationPausedBy]
+	status.Reason = annotations[uisrv.AnnotationPauseReason]
+	if t, err := time.Parse(time.RFC3339, annotations[uisrv.AnnotationPausedAt]); err == nil {
+		status.PausedAt = &metav1.Time{Time: t}
+	}
+	return status
+}
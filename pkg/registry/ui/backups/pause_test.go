@@ -0,0 +1,76 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"testing"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recordingUserClient returns kc for every user and records who the writes were made for
+func recordingUserClient(kc client.Client, users *[]string) shared.UserClient {
+	return func(u user.Info) (client.Client, error) {
+		*users = append(*users, u.GetName())
+		return kc, nil
+	}
+}
+
+func TestPause(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "demo"}}
+	kc := testclient.New(t, cfg)
+	ctx := requestContext("demo")
+	pause := func(name string) *uisrv.BackupPause {
+		return &uisrv.BackupPause{Spec: uisrv.BackupPauseSpec{
+			Invoker: stashv1beta1.BackupInvokerRef{Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: name},
+			Paused:  true,
+			Reason:  "maintenance",
+		}}
+	}
+
+	var users []string
+	r := NewBackupPauseStorage(kc, recordingUserClient(kc, &users), denyResources())
+	obj, err := r.Create(ctx, pause("sample"), nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := obj.(*uisrv.BackupPause).Status; !status.Changed || !status.Paused || status.PausedBy != "alice" {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if len(users) != 1 || users[0] != "alice" {
+		t.Errorf("the patch must be sent as alice, got %v", users)
+	}
+	if err := kc.Get(context.TODO(), client.ObjectKeyFromObject(cfg), cfg); err != nil || !cfg.Spec.Paused {
+		t.Errorf("BackupConfiguration not paused, err: %v", err)
+	}
+
+	// the authorization comes before the lookup, so that the existence of an
+	// invoker is not disclosed
+	r = NewBackupPauseStorage(kc, recordingUserClient(kc, &users), denyResources("patch/"+stashv1beta1.ResourcePluralBackupConfiguration))
+	if _, err := r.Create(ctx, pause("missing"), nil, &metav1.CreateOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("expected forbidden, got %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/restores"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	meta_util "kmodules.xyz/client-go/meta"
+	appcatalog "kmodules.xyz/custom-resources/apis/appcatalog/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackupRestoreStorage implements the restore subresource of BackupOverview
+type BackupRestoreStorage struct {
+	kc client.Client
+	a  authorizer.Authorizer
+	gr schema.GroupResource
+}
+
+var (
+	_ rest.Storage                  = &BackupRestoreStorage{}
+	_ rest.NamedCreater             = &BackupRestoreStorage{}
+	_ rest.GroupVersionKindProvider = &BackupRestoreStorage{}
+)
+
+func NewBackupRestoreStorage(kc client.Client, a authorizer.Authorizer) *BackupRestoreStorage {
+	return &BackupRestoreStorage{
+		kc: kc,
+		a:  a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralRestoreSession,
+		},
+	}
+}
+
+func (r *BackupRestoreStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupRestore)
+}
+
+func (r *BackupRestoreStorage) New() runtime.Object {
+	return &uisrv.BackupRestore{}
+}
+
+func (r *BackupRestoreStorage) Destroy() {}
+
+func (r *BackupRestoreStorage) Create(ctx context.Context, name string, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BackupRestore)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupRestore, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	if in.Spec.Snapshot != "" && len(in.Spec.Hosts) > 0 {
+		return nil, apierrors.NewBadRequest("snapshot and hosts are mutually exclusive")
+	}
+
+	// the backups may be restored into another namespace, so the user must be
+	// allowed to read the source configuration and its Repository
+	cfgGR := schema.GroupResource{Group: stashapi.GroupName, Resource: stashv1beta1.ResourcePluralBackupConfiguration}
+	if err := shared.Authorize(ctx, r.a, user, "get", ns, cfgGR, name); err != nil {
+		return nil, err
+	}
+	backupConfig := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, backupConfig); err != nil {
+		return nil, fmt.Errorf("failed to get BackupConfiguration, reason: %v", err)
+	}
+	if backupConfig.Spec.Target == nil {
+		return nil, apierrors.NewBadRequest("BackupConfiguration has no target")
+	}
+
+	target := backupConfig.Spec.Target.Ref
+	if in.Spec.Target != nil {
+		target = *in.Spec.Target
+	}
+	if target.Namespace == "" {
+		target.Namespace = ns
+	}
+
+	if err := shared.Authorize(ctx, r.a, user, "create", target.Namespace, r.gr, ""); err != nil {
+		return nil, err
+	}
+
+	repoKey := repositoryKey(backupConfig)
+	repoGR := schema.GroupResource{Group: stashapi.GroupName, Resource: stashv1alpha1.ResourcePluralRepository}
+	if err := shared.Authorize(ctx, r.a, user, "get", repoKey.Namespace, repoGR, repoKey.Name); err != nil {
+		return nil, err
+	}
+	repo, err := getRepository(ctx, r.kc, backupConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Repository, reason: %v", err)
+	}
+
+	planner := restores.NewPlanner(r.kc, r.a, user)
+	var warnings []string
+	rules := []stashv1beta1.Rule{{
+		Include: in.Spec.Include,
+		Exclude: in.Spec.Exclude,
+	}}
+	switch {
+	case in.Spec.Snapshot != "":
+		history, err := planner.History(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, history.Warnings...)
+		snap := history.Find(in.Spec.Snapshot)
+		if snap == nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("snapshot %s not found in the backups of Repository %s/%s", in.Spec.Snapshot, repo.Namespace, repo.Name))
+		}
+		rules[0].SourceHost = snap.Host
+		rules[0].Snapshots = []string{snap.Name}
+		rules[0].TargetHosts = targetHosts(target, snap.Host)
+	case len(in.Spec.Hosts) > 0:
+		rules = rules[:0]
+		for _, host := range in.Spec.Hosts {
+			rules = append(rules, stashv1beta1.Rule{
+				TargetHosts: targetHosts(target, host),
+				SourceHost:  host,
+				Include:     in.Spec.Include,
+				Exclude:     in.Spec.Exclude,
+			})
+		}
+	}
+
+	task, taskWarnings, err := planner.ResolveTask(ctx, target, stashv1beta1.TaskRef{})
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, taskWarnings...)
+	if task == nil && target.Kind == appcatalog.ResourceKindApp {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unable to resolve a restore task for %s %s/%s", target.Kind, target.Namespace, target.Name))
+	}
+
+	session := &stashv1beta1.RestoreSession{
+		ObjectMeta: metav1.ObjectMeta{
+			// the random suffix keeps restores created in the same second apart
+			GenerateName: meta_util.NameWithSuffix(name, strconv.FormatInt(time.Now().Unix(), 10)) + "-",
+			Namespace:    target.Namespace,
+		},
+		Spec: stashv1beta1.RestoreSessionSpec{
+			RestoreTargetSpec: stashv1beta1.RestoreTargetSpec{
+				Target: &stashv1beta1.RestoreTarget{
+					Ref:          target,
+					VolumeMounts: backupConfig.Spec.Target.VolumeMounts,
+					Rules:        rules,
+				},
+			},
+			Repository: kmapi.ObjectReference{
+				Name:      repo.Name,
+				Namespace: repo.Namespace,
+			},
+			TimeOut: in.Spec.TimeOut,
+		},
+	}
+	session.Spec.Target.Ref.Namespace = ""
+	if task != nil {
+		session.Spec.Task = stashv1beta1.TaskRef{Name: task.Name, Params: task.Params}
+	}
+
+	opts := &client.CreateOptions{}
+	if options != nil {
+		opts.DryRun = options.DryRun
+	}
+	if err := r.kc.Create(ctx, session, opts); err != nil {
+		return nil, err
+	}
+
+	result := in.DeepCopy()
+	result.Name = name
+	result.Namespace = ns
+	result.CreationTimestamp = session.CreationTimestamp
+	result.Status = uisrv.BackupRestoreStatus{
+		RestoreSession: session,
+		Warnings:       warnings,
+	}
+	return result, nil
+}
+
+// targetHosts restricts a rule to the host of the same name on targets
+// that have more than one host.
+func targetHosts(target stashv1beta1.TargetRef, host string) []string {
+	switch target.Kind {
+	case "StatefulSet", "DaemonSet":
+		return []string{host}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"testing"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+func TestRestoreAuthorization(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "demo"},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			Repository: kmapi.ObjectReference{Name: "repo"},
+			BackupConfigurationTemplateSpec: stashv1beta1.BackupConfigurationTemplateSpec{
+				Target: &stashv1beta1.BackupTarget{
+					Ref: stashv1beta1.TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"},
+				},
+			},
+		},
+	}
+	repo := &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "demo"}}
+	restore := &uisrv.BackupRestore{Spec: uisrv.BackupRestoreSpec{
+		Target: &stashv1beta1.TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", Namespace: "other"},
+	}}
+
+	cases := []struct {
+		name   string
+		denied []string
+	}{
+		{"allowed", nil},
+		{"no access to the configuration", []string{"get/" + stashv1beta1.ResourcePluralBackupConfiguration}},
+		{"no access to the repository", []string{"get/" + stashv1alpha1.ResourcePluralRepository}},
+		{"no restore in the target namespace", []string{"create/" + stashv1beta1.ResourcePluralRestoreSession}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kc := testclient.New(t, cfg.DeepCopy(), repo.DeepCopy())
+			r := NewBackupRestoreStorage(kc, denyResources(c.denied...))
+			obj, err := r.Create(requestContext("demo"), "sample", restore.DeepCopy(), nil, &metav1.CreateOptions{})
+			if c.denied != nil {
+				if !apierrors.IsForbidden(err) {
+					t.Errorf("expected forbidden, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			session := obj.(*uisrv.BackupRestore).Status.RestoreSession
+			if session.Namespace != "other" || session.Spec.Repository.Namespace != "demo" {
+				t.Errorf("unexpected RestoreSession %s/%s of Repository %s", session.Namespace, session.Name, session.Spec.Repository)
+			}
+		})
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	target := stashv1beta1.TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"}
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "demo"},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			Repository: kmapi.ObjectReference{Name: "repo"},
+			BackupConfigurationTemplateSpec: stashv1beta1.BackupConfigurationTemplateSpec{
+				Target: &stashv1beta1.BackupTarget{Ref: target},
+			},
+		},
+	}
+	repo := &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "demo"}}
+	session := &stashv1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-1", Namespace: "demo"},
+		Spec: stashv1beta1.BackupSessionSpec{
+			Invoker: stashv1beta1.BackupInvokerRef{Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: "sample"},
+		},
+		Status: stashv1beta1.BackupSessionStatus{
+			Targets: []stashv1beta1.BackupTargetStatus{{
+				Ref:   target,
+				Stats: []stashv1beta1.HostBackupStats{{Hostname: "host-0", Phase: stashv1beta1.HostBackupSucceeded, Snapshots: []stashv1beta1.SnapshotStats{{Name: "abc123"}}}},
+			}},
+		},
+	}
+	kc := testclient.New(t, cfg, repo, session)
+	r := NewBackupRestoreStorage(kc, denyResources())
+
+	// restores of the same second get different names
+	names := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		// the Snapshot API names snapshots as <repository>-<id>
+		in := &uisrv.BackupRestore{Spec: uisrv.BackupRestoreSpec{Snapshot: "repo-abc123"}}
+		obj, err := r.Create(requestContext("demo"), "sample", in, nil, &metav1.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs := obj.(*uisrv.BackupRestore).Status.RestoreSession
+		if rules := rs.Spec.Target.Rules; len(rules) != 1 || len(rules[0].Snapshots) != 1 || rules[0].Snapshots[0] != "abc123" {
+			t.Errorf("unexpected rules %+v", rules)
+		}
+		names[rs.Name] = true
+	}
+	if len(names) != 2 {
+		t.Errorf("RestoreSessions are named %v, expected two names", names)
+	}
+}
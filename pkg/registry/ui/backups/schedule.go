@@ -0,0 +1,64 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+
+	rcron "github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var cronParser = rcron.NewParser(rcron.Minute | rcron.Hour | rcron.Dom | rcron.Month | rcron.Dow)
+
+// ParseSchedule parses the schedule of a BackupConfiguration
+func ParseSchedule(schedule string) (rcron.Schedule, error) {
+	return cronParser.Parse(schedule)
+}
+
+// IsOverdue returns true if no backup succeeded within half a schedule
+// interval of the first scheduled run after the last successful backup or,
+// if there is none, after the configuration was created.
+func IsOverdue(sched rcron.Schedule, lastSuccess *metav1.Time, created, now time.Time) bool {
+	ref := created
+	if lastSuccess != nil && lastSuccess.After(ref) {
+		ref = lastSuccess.Time
+	}
+	next := sched.Next(ref)
+	if next.IsZero() {
+		return false
+	}
+	deadline := next.Add(sched.Next(next).Sub(next) / 2)
+	return now.After(deadline)
+}
+
+// LatestBackupSession returns the most recent BackupSession of an invoker
+func LatestBackupSession(sessions []stashv1beta1.BackupSession, kind, name string) *stashv1beta1.BackupSession {
+	var latest *stashv1beta1.BackupSession
+	for i := range sessions {
+		s := &sessions[i]
+		if s.Spec.Invoker.Kind != kind || s.Spec.Invoker.Name != name {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&s.CreationTimestamp) {
+			latest = s
+		}
+	}
+	return latest
+}
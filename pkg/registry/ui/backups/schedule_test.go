@@ -0,0 +1,49 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsOverdue(t *testing.T) {
+	sched, err := ParseSchedule("0 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2024, 5, 15, 8, 30, 0, 0, time.UTC)
+	last := metav1.NewTime(time.Date(2024, 5, 15, 10, 0, 5, 0, time.UTC))
+
+	cases := []struct {
+		last     *metav1.Time
+		now      time.Time
+		expected bool
+	}{
+		{nil, time.Date(2024, 5, 15, 9, 20, 0, 0, time.UTC), false},
+		{nil, time.Date(2024, 5, 15, 9, 40, 0, 0, time.UTC), true},
+		{&last, time.Date(2024, 5, 15, 11, 20, 0, 0, time.UTC), false},
+		{&last, time.Date(2024, 5, 15, 11, 31, 0, 0, time.UTC), true},
+	}
+	for _, c := range cases {
+		if got := IsOverdue(sched, c.last, created, c.now); got != c.expected {
+			t.Errorf("IsOverdue(%v, %v) = %v, expected %v", c.last, c.now, got, c.expected)
+		}
+	}
+}
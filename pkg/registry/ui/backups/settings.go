@@ -0,0 +1,246 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackupSettingsStorage implements the settings subresource of BackupOverview
+type BackupSettingsStorage struct {
+	kc client.Client
+	a  authorizer.Authorizer
+	gr schema.GroupResource
+}
+
+var (
+	_ rest.Storage                  = &BackupSettingsStorage{}
+	_ rest.Patcher                  = &BackupSettingsStorage{}
+	_ rest.GroupVersionKindProvider = &BackupSettingsStorage{}
+)
+
+func NewBackupSettingsStorage(kc client.Client, a authorizer.Authorizer) *BackupSettingsStorage {
+	return &BackupSettingsStorage{
+		kc: kc,
+		a:  a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupConfiguration,
+		},
+	}
+}
+
+func (r *BackupSettingsStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupSettings)
+}
+
+func (r *BackupSettingsStorage) New() runtime.Object {
+	return &uisrv.BackupSettings{}
+}
+
+func (r *BackupSettingsStorage) Destroy() {}
+
+func (r *BackupSettingsStorage) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	backupConfig, err := r.getBackupConfiguration(ctx, "get", name)
+	if err != nil {
+		return nil, err
+	}
+	return newBackupSettings(backupConfig), nil
+}
+
+func (r *BackupSettingsStorage) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, _ rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, _ bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest("missing user info")
+	}
+
+	backupConfig, err := r.getBackupConfiguration(ctx, "update", name)
+	if err != nil {
+		return nil, false, err
+	}
+	old := newBackupSettings(backupConfig)
+	obj, err := objInfo.UpdatedObject(ctx, old)
+	if err != nil {
+		return nil, false, err
+	}
+	in, ok := obj.(*uisrv.BackupSettings)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupSettings, obj))
+	}
+	if updateValidation != nil {
+		if err := updateValidation(ctx, obj.DeepCopyObject(), old); err != nil {
+			return nil, false, err
+		}
+	}
+
+	specPath := field.NewPath("spec")
+	errs := validateSchedule(specPath.Child("schedule"), in.Spec.Schedule)
+	if in.Spec.TimeOut != nil && in.Spec.TimeOut.Duration <= 0 {
+		errs = append(errs, field.Invalid(specPath.Child("timeOut"), in.Spec.TimeOut.Duration.String(), "must be positive"))
+	}
+	if in.Spec.RetryConfig != nil && in.Spec.RetryConfig.MaxRetry < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("retryConfig", "maxRetry"), in.Spec.RetryConfig.MaxRetry, "must not be negative"))
+	}
+	errs = append(errs, validateRetentionPolicy(specPath.Child("retentionPolicy"), in.Spec.RetentionPolicy)...)
+	if in.Spec.BackupHistoryLimit != nil && *in.Spec.BackupHistoryLimit < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("backupHistoryLimit"), *in.Spec.BackupHistoryLimit, "must not be negative"))
+	}
+	if len(errs) > 0 {
+		return nil, false, apierrors.NewInvalid(uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupSettings).GroupKind(), name, errs)
+	}
+
+	backupConfig.Spec.Schedule = in.Spec.Schedule
+	markPaused(backupConfig, in.Spec.Paused, user.GetName(), "")
+	backupConfig.Spec.RetentionPolicy = in.Spec.RetentionPolicy
+	backupConfig.Spec.TimeOut = in.Spec.TimeOut
+	backupConfig.Spec.RetryConfig = in.Spec.RetryConfig
+	backupConfig.Spec.BackupHistoryLimit = in.Spec.BackupHistoryLimit
+	if err := updateBackupConfiguration(ctx, r.kc, r.gr, backupConfig, in.ResourceVersion, options); err != nil {
+		return nil, false, err
+	}
+	return newBackupSettings(backupConfig), false, nil
+}
+
+func (r *BackupSettingsStorage) getBackupConfiguration(ctx context.Context, verb, name string) (*stashv1beta1.BackupConfiguration, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            user,
+		Verb:            verb,
+		Namespace:       ns,
+		APIGroup:        r.gr.Group,
+		Resource:        r.gr.Resource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, why, err := r.a.Authorize(ctx, attrs)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return nil, apierrors.NewForbidden(r.gr, name, errors.New(why))
+	}
+
+	backupConfig := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, backupConfig); err != nil {
+		return nil, err
+	}
+	return backupConfig, nil
+}
+
+func newBackupSettings(cfg *stashv1beta1.BackupConfiguration) *uisrv.BackupSettings {
+	return &uisrv.BackupSettings{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              cfg.Name,
+			Namespace:         cfg.Namespace,
+			UID:               cfg.UID,
+			ResourceVersion:   cfg.ResourceVersion,
+			Generation:        cfg.Generation,
+			CreationTimestamp: cfg.CreationTimestamp,
+		},
+		Spec: uisrv.BackupSettingsSpec{
+			Schedule:           cfg.Spec.Schedule,
+			Paused:             cfg.Spec.Paused,
+			RetentionPolicy:    cfg.Spec.RetentionPolicy,
+			TimeOut:            cfg.Spec.TimeOut,
+			RetryConfig:        cfg.Spec.RetryConfig,
+			BackupHistoryLimit: cfg.Spec.BackupHistoryLimit,
+		},
+	}
+}
+
+// validateSchedule checks a cron schedule with the parser used for the overview
+func validateSchedule(path *field.Path, schedule string) field.ErrorList {
+	if schedule == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	if _, err := ParseSchedule(schedule); err != nil {
+		return field.ErrorList{field.Invalid(path, schedule, err.Error())}
+	}
+	return nil
+}
+
+// validateRetentionPolicy checks that a retention policy is named and keeps
+// some snapshots, as a policy without keep rules makes restic fail to forget.
+func validateRetentionPolicy(path *field.Path, policy stashv1alpha1.RetentionPolicy) field.ErrorList {
+	var errs field.ErrorList
+	if policy.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), ""))
+	}
+	hasRule := len(policy.KeepTags) > 0
+	for _, keep := range []struct {
+		name string
+		n    int64
+	}{
+		{"keepLast", policy.KeepLast},
+		{"keepHourly", policy.KeepHourly},
+		{"keepDaily", policy.KeepDaily},
+		{"keepWeekly", policy.KeepWeekly},
+		{"keepMonthly", policy.KeepMonthly},
+		{"keepYearly", policy.KeepYearly},
+	} {
+		switch {
+		case keep.n < 0:
+			errs = append(errs, field.Invalid(path.Child(keep.name), keep.n, "must not be negative"))
+		case keep.n > 0:
+			hasRule = true
+		}
+	}
+	if !hasRule {
+		errs = append(errs, field.Required(path, "at least one keep rule is required"))
+	}
+	return errs
+}
+
+// updateBackupConfiguration writes back a BackupConfiguration. A non-empty
+// resourceVersion must match the one the changes were made against.
+func updateBackupConfiguration(ctx context.Context, kc client.Client, gr schema.GroupResource, cfg *stashv1beta1.BackupConfiguration, resourceVersion string, options *metav1.UpdateOptions) error {
+	if resourceVersion != "" && resourceVersion != cfg.ResourceVersion {
+		return apierrors.NewConflict(gr, cfg.Name, errors.New(genericregistry.OptimisticLockErrorMsg))
+	}
+	opts := &client.UpdateOptions{}
+	if options != nil {
+		opts.DryRun = options.DryRun
+	}
+	return kc.Update(ctx, cfg, opts)
+}
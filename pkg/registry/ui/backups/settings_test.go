@@ -0,0 +1,45 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"testing"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateRetentionPolicy(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy stashv1alpha1.RetentionPolicy
+		errs   int
+	}{
+		{"keep last", stashv1alpha1.RetentionPolicy{Name: "keep-last-5", KeepLast: 5, Prune: true}, 0},
+		{"keep tags", stashv1alpha1.RetentionPolicy{Name: "keep-tagged", KeepTags: []string{"release"}}, 0},
+		{"no name", stashv1alpha1.RetentionPolicy{KeepDaily: 7}, 1},
+		{"no keep rule", stashv1alpha1.RetentionPolicy{Name: "empty", Prune: true}, 1},
+		{"negative", stashv1alpha1.RetentionPolicy{Name: "negative", KeepLast: 5, KeepWeekly: -1}, 1},
+		{"empty", stashv1alpha1.RetentionPolicy{}, 2},
+	}
+	for _, c := range cases {
+		if errs := validateRetentionPolicy(field.NewPath("spec", "retentionPolicy"), c.policy); len(errs) != c.errs {
+			t.Errorf("%s: got errors %v, expected %d", c.name, errs, c.errs)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	meta_util "kmodules.xyz/client-go/meta"
+	store "kmodules.xyz/objectstore-api/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultS3Endpoint = "s3.amazonaws.com"
+
+// setupRollbackTimeout bounds the removal of the Repository of a failed setup
+const setupRollbackTimeout = 30 * time.Second
+
+var retentionPresets = map[uisrv.RetentionPreset]stashv1alpha1.RetentionPolicy{
+	uisrv.RetentionPresetMinimal: {KeepLast: 5},
+	uisrv.RetentionPresetDaily:   {KeepDaily: 7},
+	uisrv.RetentionPresetWeekly:  {KeepDaily: 7, KeepWeekly: 4},
+	uisrv.RetentionPresetMonthly: {KeepDaily: 7, KeepWeekly: 4, KeepMonthly: 12},
+}
+
+type BackupSetupStorage struct {
+	kc        client.Client
+	a         authorizer.Authorizer
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &BackupSetupStorage{}
+	_ rest.Scoper                   = &BackupSetupStorage{}
+	_ rest.Storage                  = &BackupSetupStorage{}
+	_ rest.Creater                  = &BackupSetupStorage{}
+	_ rest.SingularNameProvider     = &BackupSetupStorage{}
+)
+
+func NewBackupSetupStorage(kc client.Client, a authorizer.Authorizer) *BackupSetupStorage {
+	return &BackupSetupStorage{
+		kc: kc,
+		a:  a,
+		convertor: rest.NewDefaultTableConvertor(schema.GroupResource{
+			Group:    ui.GroupName,
+			Resource: uisrv.ResourceBackupSetups,
+		}),
+	}
+}
+
+func (r *BackupSetupStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupSetup)
+}
+
+func (r *BackupSetupStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindBackupSetup)
+}
+
+func (r *BackupSetupStorage) NamespaceScoped() bool {
+	return true
+}
+
+func (r *BackupSetupStorage) New() runtime.Object {
+	return &uisrv.BackupSetup{}
+}
+
+func (r *BackupSetupStorage) Destroy() {}
+
+func (r *BackupSetupStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BackupSetup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupSetup, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	if errs := validateBackupSetup(in); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupSetup).GroupKind(), in.Name, errs)
+	}
+
+	repo, backupConfig := newBackupSetupObjects(ns, in)
+	for _, gr := range []schema.GroupResource{
+		{Group: stashapi.GroupName, Resource: stashv1alpha1.ResourcePluralRepository},
+		{Group: stashapi.GroupName, Resource: stashv1beta1.ResourcePluralBackupConfiguration},
+	} {
+		attrs := authorizer.AttributesRecord{
+			User:            user,
+			Verb:            "create",
+			Namespace:       ns,
+			APIGroup:        gr.Group,
+			Resource:        gr.Resource,
+			ResourceRequest: true,
+		}
+		decision, why, err := r.a.Authorize(ctx, attrs)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		if decision != authorizer.DecisionAllow {
+			return nil, apierrors.NewForbidden(gr, "", errors.New(why))
+		}
+	}
+
+	opts := &client.CreateOptions{}
+	if options != nil {
+		opts.DryRun = options.DryRun
+	}
+	if len(opts.DryRun) == 0 {
+		// catch the failures of the BackupConfiguration, e.g. a name in use or a
+		// rejection by a webhook, before anything is created
+		if err := r.kc.Create(ctx, backupConfig.DeepCopy(), client.DryRunAll); err != nil {
+			return nil, fmt.Errorf("failed to create BackupConfiguration, reason: %w", err)
+		}
+	}
+	if err := r.kc.Create(ctx, repo, opts); err != nil {
+		return nil, fmt.Errorf("failed to create Repository, reason: %w", err)
+	}
+	if err := r.kc.Create(ctx, backupConfig, opts); err != nil {
+		if len(opts.DryRun) == 0 {
+			if e2 := r.rollback(repo); e2 != nil {
+				return nil, fmt.Errorf("failed to create BackupConfiguration, reason: %w; the Repository %s/%s could not be removed, reason: %v", err, repo.Namespace, repo.Name, e2)
+			}
+		}
+		return nil, fmt.Errorf("failed to create BackupConfiguration, reason: %w", err)
+	}
+
+	result := in.DeepCopy()
+	result.Namespace = ns
+	result.CreationTimestamp = backupConfig.CreationTimestamp
+	result.UID = backupConfig.UID
+	result.Status = uisrv.BackupSetupStatus{
+		Repository:          repo.Name,
+		BackupConfiguration: backupConfig.Name,
+	}
+	return result, nil
+}
+
+// rollback deletes the Repository created for a setup that failed. It does
+// not use the request context, which is often cancelled by then.
+func (r *BackupSetupStorage) rollback(repo *stashv1alpha1.Repository) error {
+	ctx, cancel := context.WithTimeout(context.Background(), setupRollbackTimeout)
+	defer cancel()
+
+	err := r.kc.Delete(ctx, repo, client.Preconditions{UID: &repo.UID})
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("failed to roll back Repository %s/%s, reason: %v", repo.Namespace, repo.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (r *BackupSetupStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
+
+func validateBackupSetup(in *uisrv.BackupSetup) field.ErrorList {
+	var errs field.ErrorList
+	if in.Name == "" {
+		errs = append(errs, field.Required(field.NewPath("metadata", "name"), ""))
+	}
+	specPath := field.NewPath("spec")
+	if in.Spec.Target.Kind == "" {
+		errs = append(errs, field.Required(specPath.Child("target", "kind"), ""))
+	}
+	if in.Spec.Target.Name == "" {
+		errs = append(errs, field.Required(specPath.Child("target", "name"), ""))
+	}
+	backendPath := specPath.Child("backend")
+	switch in.Spec.Backend.Type {
+	case uisrv.BackendTypeS3, uisrv.BackendTypeGCS, uisrv.BackendTypeAzure, uisrv.BackendTypeSwift, uisrv.BackendTypeB2:
+	default:
+		errs = append(errs, field.NotSupported(backendPath.Child("type"), in.Spec.Backend.Type, []string{
+			string(uisrv.BackendTypeS3), string(uisrv.BackendTypeGCS), string(uisrv.BackendTypeAzure), string(uisrv.BackendTypeSwift), string(uisrv.BackendTypeB2),
+		}))
+	}
+	if in.Spec.Backend.Bucket == "" {
+		errs = append(errs, field.Required(backendPath.Child("bucket"), ""))
+	}
+	if in.Spec.Backend.StorageSecretName == "" {
+		errs = append(errs, field.Required(backendPath.Child("storageSecretName"), ""))
+	}
+	errs = append(errs, validateSchedule(specPath.Child("schedule"), in.Spec.Schedule)...)
+	if _, ok := retentionPresets[in.Spec.RetentionPreset]; !ok && in.Spec.RetentionPreset != "" {
+		errs = append(errs, field.NotSupported(specPath.Child("retentionPreset"), in.Spec.RetentionPreset, []string{
+			string(uisrv.RetentionPresetMinimal), string(uisrv.RetentionPresetDaily), string(uisrv.RetentionPresetWeekly), string(uisrv.RetentionPresetMonthly),
+		}))
+	}
+	return errs
+}
+
+// newBackupSetupObjects expands a BackupSetup into a Repository and a
+// BackupConfiguration. The BackupConfiguration is named after the setup.
+func newBackupSetupObjects(ns string, in *uisrv.BackupSetup) (*stashv1alpha1.Repository, *stashv1beta1.BackupConfiguration) {
+	prefix := in.Spec.Backend.Prefix
+	if prefix == "" {
+		prefix = path.Join(ns, in.Name)
+	}
+	backend := store.Backend{
+		StorageSecretName: in.Spec.Backend.StorageSecretName,
+	}
+	switch in.Spec.Backend.Type {
+	case uisrv.BackendTypeS3:
+		endpoint := in.Spec.Backend.Endpoint
+		if endpoint == "" {
+			endpoint = defaultS3Endpoint
+		}
+		backend.S3 = &store.S3Spec{Endpoint: endpoint, Bucket: in.Spec.Backend.Bucket, Prefix: prefix, Region: in.Spec.Backend.Region}
+	case uisrv.BackendTypeGCS:
+		backend.GCS = &store.GCSSpec{Bucket: in.Spec.Backend.Bucket, Prefix: prefix}
+	case uisrv.BackendTypeAzure:
+		backend.Azure = &store.AzureSpec{Container: in.Spec.Backend.Bucket, Prefix: prefix}
+	case uisrv.BackendTypeSwift:
+		backend.Swift = &store.SwiftSpec{Container: in.Spec.Backend.Bucket, Prefix: prefix}
+	case uisrv.BackendTypeB2:
+		backend.B2 = &store.B2Spec{Bucket: in.Spec.Backend.Bucket, Prefix: prefix}
+	}
+
+	repo := &stashv1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      meta_util.NameWithSuffix(in.Name, "repo"),
+			Namespace: ns,
+			Labels:    in.Labels,
+		},
+		Spec: stashv1alpha1.RepositorySpec{
+			Backend: backend,
+		},
+	}
+
+	preset := in.Spec.RetentionPreset
+	if preset == "" {
+		preset = uisrv.RetentionPresetDaily
+	}
+	retention := retentionPresets[preset]
+	retention.Name = strings.ToLower(string(preset))
+	retention.Prune = true
+
+	backupConfig := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      in.Name,
+			Namespace: ns,
+			Labels:    in.Labels,
+		},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			BackupConfigurationTemplateSpec: stashv1beta1.BackupConfigurationTemplateSpec{
+				Task: stashv1beta1.TaskRef{Name: in.Spec.Task},
+				Target: &stashv1beta1.BackupTarget{
+					Ref:          in.Spec.Target,
+					Paths:        in.Spec.Paths,
+					VolumeMounts: in.Spec.VolumeMounts,
+				},
+			},
+			Schedule:        in.Spec.Schedule,
+			Repository:      kmapi.ObjectReference{Name: repo.Name},
+			RetentionPolicy: retention,
+		},
+	}
+	return repo, backupConfig
+}
@@ -0,0 +1,138 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newBackupSetup() *uisrv.BackupSetup {
+	return &uisrv.BackupSetup{
+		ObjectMeta: metav1.ObjectMeta{Name: "app"},
+		Spec: uisrv.BackupSetupSpec{
+			Target: stashv1beta1.TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"},
+			Backend: uisrv.BackupSetupBackend{
+				Type:              uisrv.BackendTypeS3,
+				Bucket:            "backups",
+				StorageSecretName: "s3-secret",
+			},
+			Schedule: "0 * * * *",
+		},
+	}
+}
+
+func TestValidateBackupSetup(t *testing.T) {
+	if errs := validateBackupSetup(newBackupSetup()); len(errs) > 0 {
+		t.Errorf("unexpected errors %v", errs)
+	}
+
+	in := newBackupSetup()
+	in.Name = ""
+	in.Spec.Target.Kind = ""
+	in.Spec.Backend.Type = "ftp"
+	in.Spec.Backend.StorageSecretName = ""
+	in.Spec.Schedule = "every hour"
+	in.Spec.RetentionPreset = "forever"
+	var fields []string
+	for _, err := range validateBackupSetup(in) {
+		fields = append(fields, err.Field)
+	}
+	want := []string{"metadata.name", "spec.target.kind", "spec.backend.type", "spec.backend.storageSecretName", "spec.schedule", "spec.retentionPreset"}
+	if len(fields) != len(want) {
+		t.Fatalf("errors on %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("errors on %v, want %v", fields, want)
+			break
+		}
+	}
+}
+
+func TestNewBackupSetupObjects(t *testing.T) {
+	repo, cfg := newBackupSetupObjects("demo", newBackupSetup())
+	if repo.Name != "app-repo" || repo.Namespace != "demo" {
+		t.Errorf("unexpected Repository %s/%s", repo.Namespace, repo.Name)
+	}
+	if s3 := repo.Spec.Backend.S3; s3 == nil || s3.Endpoint != defaultS3Endpoint || s3.Prefix != "demo/app" || s3.Bucket != "backups" {
+		t.Errorf("unexpected backend %+v", repo.Spec.Backend)
+	}
+	if cfg.Name != "app" || cfg.Spec.Repository.Name != repo.Name || cfg.Spec.Target.Ref.Name != "app" {
+		t.Errorf("unexpected BackupConfiguration %+v", cfg)
+	}
+	if r := cfg.Spec.RetentionPolicy; r.Name != "daily" || r.KeepDaily != 7 || !r.Prune {
+		t.Errorf("unexpected retention policy %+v", r)
+	}
+}
+
+func TestBackupSetupRollback(t *testing.T) {
+	// the dry run of the BackupConfiguration passes, the actual create fails
+	kc := testclient.NewBuilder(t).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			co := &client.CreateOptions{}
+			co.ApplyOptions(opts)
+			if _, ok := obj.(*stashv1beta1.BackupConfiguration); ok && len(co.DryRun) == 0 {
+				return errors.New("connection reset")
+			}
+			if len(co.DryRun) > 0 {
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+
+	r := NewBackupSetupStorage(kc, denyResources())
+	if _, err := r.Create(requestContext("demo"), newBackupSetup(), nil, &metav1.CreateOptions{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	var repos stashv1alpha1.RepositoryList
+	if err := kc.List(context.TODO(), &repos); err != nil {
+		t.Fatal(err)
+	}
+	if len(repos.Items) != 0 {
+		t.Errorf("the Repository must be rolled back, found %d", len(repos.Items))
+	}
+}
+
+func TestBackupSetupNameInUse(t *testing.T) {
+	existing := &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "demo"}}
+	kc := testclient.New(t, existing)
+
+	r := NewBackupSetupStorage(kc, denyResources())
+	if _, err := r.Create(requestContext("demo"), newBackupSetup(), nil, &metav1.CreateOptions{}); !apierrors.IsAlreadyExists(err) {
+		t.Fatalf("expected already exists, got %v", err)
+	}
+	var repos stashv1alpha1.RepositoryList
+	if err := kc.List(context.TODO(), &repos); err != nil {
+		t.Fatal(err)
+	}
+	if len(repos.Items) != 0 {
+		t.Errorf("no Repository must be created, found %d", len(repos.Items))
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// stashSidecarName is the name of the container injected by the Stash operator
+const stashSidecarName = "stash"
+
+// BackupSidecarStorage implements the sidecar subresource of BackupOverview
+type BackupSidecarStorage struct {
+	kc client.Client
+	// apiReader reads the target workloads, which are not cached. Pods are never cached.
+	apiReader client.Reader
+	a         authorizer.Authorizer
+	gr        schema.GroupResource
+}
+
+var (
+	_ rest.Storage                  = &BackupSidecarStorage{}
+	_ rest.Getter                   = &BackupSidecarStorage{}
+	_ rest.GroupVersionKindProvider = &BackupSidecarStorage{}
+)
+
+func NewBackupSidecarStorage(kc client.Client, apiReader client.Reader, a authorizer.Authorizer) *BackupSidecarStorage {
+	return &BackupSidecarStorage{
+		kc:        kc,
+		apiReader: apiReader,
+		a:         a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupConfiguration,
+		},
+	}
+}
+
+func (r *BackupSidecarStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupSidecar)
+}
+
+func (r *BackupSidecarStorage) New() runtime.Object {
+	return &uisrv.BackupSidecar{}
+}
+
+func (r *BackupSidecarStorage) Destroy() {}
+
+func (r *BackupSidecarStorage) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	u, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	if err := shared.Authorize(ctx, r.a, u, "get", ns, r.gr, name); err != nil {
+		return nil, err
+	}
+
+	cfg := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, cfg); err != nil {
+		return nil, err
+	}
+	status, err := r.sidecarStatus(ctx, u, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &uisrv.BackupSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              cfg.Name,
+			Namespace:         cfg.Namespace,
+			UID:               cfg.UID,
+			CreationTimestamp: cfg.CreationTimestamp,
+		},
+		Status: status,
+	}, nil
+}
+
+// sidecarStatus reads the target workload and its Pods as the server, so the
+// user u must be allowed to get the target and list the Pods in its namespace.
+func (r *BackupSidecarStorage) sidecarStatus(ctx context.Context, u user.Info, cfg *stashv1beta1.BackupConfiguration) (uisrv.BackupSidecarStatus, error) {
+	if cfg.Spec.Target == nil {
+		return uisrv.BackupSidecarStatus{}, nil
+	}
+	ref := cfg.Spec.Target.Ref
+	ns := ref.Namespace
+	if ns == "" {
+		ns = cfg.Namespace
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil || gv.Group != apps.GroupName {
+		return uisrv.BackupSidecarStatus{}, nil
+	}
+
+	// selector and template point into obj, so they are set by the Get
+	var (
+		resource string
+		obj      client.Object
+		selector **metav1.LabelSelector
+		template *core.PodTemplateSpec
+	)
+	switch ref.Kind {
+	case "Deployment":
+		d := &apps.Deployment{}
+		resource, obj, selector, template = "deployments", d, &d.Spec.Selector, &d.Spec.Template
+	case "StatefulSet":
+		sts := &apps.StatefulSet{}
+		resource, obj, selector, template = "statefulsets", sts, &sts.Spec.Selector, &sts.Spec.Template
+	default:
+		return uisrv.BackupSidecarStatus{}, nil
+	}
+	if err := shared.Authorize(ctx, r.a, u, "get", ns, schema.GroupResource{Group: apps.GroupName, Resource: resource}, ref.Name); err != nil {
+		return uisrv.BackupSidecarStatus{}, err
+	}
+	if err := shared.Authorize(ctx, r.a, u, "list", ns, schema.GroupResource{Group: core.GroupName, Resource: "pods"}, ""); err != nil {
+		return uisrv.BackupSidecarStatus{}, err
+	}
+
+	key := client.ObjectKey{Namespace: ns, Name: ref.Name}
+	if err := r.apiReader.Get(ctx, key, obj); err != nil {
+		return targetNotFound(ref.Kind, key, err)
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(*selector)
+	if err != nil {
+		return uisrv.BackupSidecarStatus{}, err
+	}
+	var pods core.PodList
+	if err := r.kc.List(ctx, &pods, client.InNamespace(ns), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return uisrv.BackupSidecarStatus{}, err
+	}
+
+	status := CheckSidecar(cfg, template, pods.Items)
+	status.Target = fmt.Sprintf("%s %s/%s", ref.Kind, ns, ref.Name)
+	return status, nil
+}
+
+// targetNotFound reports a missing target as a warning
+func targetNotFound(kind string, key client.ObjectKey, err error) (uisrv.BackupSidecarStatus, error) {
+	if !apierrors.IsNotFound(err) {
+		return uisrv.BackupSidecarStatus{}, err
+	}
+	return uisrv.BackupSidecarStatus{
+		Applicable: true,
+		Target:     fmt.Sprintf("%s %s", kind, key),
+		Warnings:   []string{fmt.Sprintf("%s %s not found", kind, key)},
+	}, nil
+}
+
+// CheckSidecar compares the Pods of the target workload of a BackupConfiguration
+// with its pod template. Pods that are terminating or finished are ignored.
+func CheckSidecar(cfg *stashv1beta1.BackupConfiguration, template *core.PodTemplateSpec, pods []core.Pod) uisrv.BackupSidecarStatus {
+	status := uisrv.BackupSidecarStatus{
+		Applicable: true,
+		SpecHash:   template.Annotations[stashv1beta1.AppliedBackupInvokerSpecHash],
+	}
+	for _, c := range cfg.Status.Conditions {
+		if c.Type == stashv1beta1.StashSidecarInjected {
+			status.Injected = c.Status == metav1.ConditionTrue
+			status.InjectionMessage = c.Message
+		}
+	}
+	if !hasSidecar(template.Spec.Containers) {
+		status.Warnings = append(status.Warnings, "the pod template has no stash sidecar")
+	}
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == core.PodSucceeded || pod.Status.Phase == core.PodFailed {
+			continue
+		}
+		status.Pods++
+		switch {
+		case !hasSidecar(pod.Spec.Containers):
+			status.Missing++
+			status.MissingPods = append(status.MissingPods, pod.Name)
+		case pod.Annotations[stashv1beta1.AppliedBackupInvokerSpecHash] != status.SpecHash:
+			status.Stale++
+			status.StalePods = append(status.StalePods, pod.Name)
+		default:
+			status.UpToDate++
+		}
+	}
+	sort.Strings(status.MissingPods)
+	sort.Strings(status.StalePods)
+
+	if status.Stale > 0 {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("%d Pods run an outdated sidecar and need a restart", status.Stale))
+	}
+	if status.Missing > 0 {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("%d Pods don't run the sidecar", status.Missing))
+	}
+	return status
+}
+
+func hasSidecar(containers []core.Container) bool {
+	for _, c := range containers {
+		if c.Name == stashSidecarName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"reflect"
+	"testing"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+type sidecarCounts struct {
+	Pods, UpToDate, Stale, Missing int32
+	StalePods, MissingPods         []string
+}
+
+func TestCheckSidecar(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{
+		Status: stashv1beta1.BackupConfigurationStatus{
+			Conditions: []kmapi.Condition{{Type: stashv1beta1.StashSidecarInjected, Status: metav1.ConditionTrue}},
+		},
+	}
+	withSidecar := []core.Container{{Name: "app"}, {Name: stashSidecarName}}
+	template := &core.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{stashv1beta1.AppliedBackupInvokerSpecHash: "new"}},
+		Spec:       core.PodSpec{Containers: withSidecar},
+	}
+	pod := func(name, hash string, containers []core.Container, phase core.PodPhase) core.Pod {
+		return core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{stashv1beta1.AppliedBackupInvokerSpecHash: hash}},
+			Spec:       core.PodSpec{Containers: containers},
+			Status:     core.PodStatus{Phase: phase},
+		}
+	}
+	terminating := pod("app-4", "", nil, core.PodRunning)
+	terminating.DeletionTimestamp = &metav1.Time{}
+
+	status := CheckSidecar(cfg, template, []core.Pod{
+		pod("app-0", "new", withSidecar, core.PodRunning),
+		pod("app-1", "old", withSidecar, core.PodRunning),
+		pod("app-2", "", []core.Container{{Name: "app"}}, core.PodRunning),
+		pod("app-3", "old", withSidecar, core.PodSucceeded),
+		terminating,
+	})
+	want := sidecarCounts{Pods: 3, UpToDate: 1, Stale: 1, Missing: 1, StalePods: []string{"app-1"}, MissingPods: []string{"app-2"}}
+	got := sidecarCounts{status.Pods, status.UpToDate, status.Stale, status.Missing, status.StalePods, status.MissingPods}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if !status.Injected || status.SpecHash != "new" || len(status.Warnings) != 2 {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestSidecarAuthorization(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "demo"},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			BackupConfigurationTemplateSpec: stashv1beta1.BackupConfigurationTemplateSpec{
+				Target: &stashv1beta1.BackupTarget{
+					Ref: stashv1beta1.TargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", Namespace: "other"},
+				},
+			},
+		},
+	}
+	deployment := &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "other"},
+		Spec:       apps.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}}},
+	}
+
+	for _, c := range []struct {
+		name      string
+		denied    []string
+		forbidden bool
+	}{
+		{name: "allowed"},
+		{name: "configuration", denied: []string{"get/backupconfigurations"}, forbidden: true},
+		{name: "target", denied: []string{"get/deployments"}, forbidden: true},
+		{name: "pods", denied: []string{"list/pods"}, forbidden: true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			kc := testclient.New(t, cfg, deployment)
+			obj, err := NewBackupSidecarStorage(kc, kc, denyResources(c.denied...)).Get(requestContext("demo"), cfg.Name, nil)
+			if c.forbidden {
+				if !apierrors.IsForbidden(err) {
+					t.Fatalf("expected a forbidden error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if target := obj.(*uisrv.BackupSidecar).Status.Target; target != "Deployment other/app" {
+				t.Errorf("target = %q, expected Deployment other/app", target)
+			}
+		})
+	}
+}
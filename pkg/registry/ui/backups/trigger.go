@@ -0,0 +1,156 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	meta_util "kmodules.xyz/client-go/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackupTriggerStorage implements the trigger subresource of BackupOverview
+type BackupTriggerStorage struct {
+	kc client.Client
+	a  authorizer.Authorizer
+	gr schema.GroupResource
+}
+
+var (
+	_ rest.Storage                  = &BackupTriggerStorage{}
+	_ rest.NamedCreater             = &BackupTriggerStorage{}
+	_ rest.GroupVersionKindProvider = &BackupTriggerStorage{}
+)
+
+func NewBackupTriggerStorage(kc client.Client, a authorizer.Authorizer) *BackupTriggerStorage {
+	return &BackupTriggerStorage{
+		kc: kc,
+		a:  a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupSession,
+		},
+	}
+}
+
+func (r *BackupTriggerStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupTrigger)
+}
+
+func (r *BackupTriggerStorage) New() runtime.Object {
+	return &uisrv.BackupTrigger{}
+}
+
+func (r *BackupTriggerStorage) Destroy() {}
+
+func (r *BackupTriggerStorage) Create(ctx context.Context, name string, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BackupTrigger)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupTrigger, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	cfgGR := schema.GroupResource{Group: stashapi.GroupName, Resource: stashv1beta1.ResourcePluralBackupConfiguration}
+	if err := shared.Authorize(ctx, r.a, user, "get", ns, cfgGR, name); err != nil {
+		return nil, err
+	}
+	if err := shared.Authorize(ctx, r.a, user, "create", ns, r.gr, ""); err != nil {
+		return nil, err
+	}
+
+	backupConfig := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, backupConfig); err != nil {
+		return nil, fmt.Errorf("failed to get BackupConfiguration, reason: %v", err)
+	}
+	if backupConfig.Spec.Paused {
+		return nil, apierrors.NewConflict(r.gr, name, errors.New("BackupConfiguration is paused"))
+	}
+
+	session := newBackupSession(backupConfig.ObjectMeta, stashv1beta1.ResourceKindBackupConfiguration)
+	opts := &client.CreateOptions{}
+	if options != nil {
+		opts.DryRun = options.DryRun
+	}
+	if err := r.kc.Create(ctx, session, opts); err != nil {
+		return nil, err
+	}
+
+	result := in.DeepCopy()
+	result.Name = name
+	result.Namespace = ns
+	result.CreationTimestamp = session.CreationTimestamp
+	result.Status = uisrv.BackupTriggerStatus{
+		BackupSession: session.Name,
+		Invoker:       session.Spec.Invoker,
+	}
+	return result, nil
+}
+
+// newBackupSession creates a BackupSession for an invoker the same way
+// `kubectl stash trigger` does. The name gets a random suffix, so that it does
+// not collide with a session created in the same second by the CronJob or
+// by another trigger.
+func newBackupSession(invoker metav1.ObjectMeta, kind string) *stashv1beta1.BackupSession {
+	return &stashv1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: meta_util.NameWithSuffix(invoker.Name, strconv.FormatInt(time.Now().Unix(), 10)) + "-",
+			Namespace:    invoker.Namespace,
+			Labels: map[string]string{
+				shared.LabelApp:         shared.AppLabelStash,
+				shared.LabelInvokerType: kind,
+				shared.LabelInvokerName: invoker.Name,
+			},
+		},
+		Spec: stashv1beta1.BackupSessionSpec{
+			Invoker: stashv1beta1.BackupInvokerRef{
+				APIGroup: stashapi.GroupName,
+				Kind:     kind,
+				Name:     invoker.Name,
+			},
+		},
+	}
+}
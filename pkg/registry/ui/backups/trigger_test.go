@@ -0,0 +1,61 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backups
+
+import (
+	"testing"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTrigger(t *testing.T) {
+	cfg := &stashv1beta1.BackupConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "demo"}}
+	paused := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "paused", Namespace: "demo"},
+		Spec:       stashv1beta1.BackupConfigurationSpec{Paused: true},
+	}
+	kc := testclient.New(t, cfg, paused)
+	ctx := requestContext("demo")
+
+	// triggers within the same second must not collide
+	r := NewBackupTriggerStorage(kc, denyResources())
+	names := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		obj, err := r.Create(ctx, "sample", &uisrv.BackupTrigger{}, nil, &metav1.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		names[obj.(*uisrv.BackupTrigger).Status.BackupSession] = true
+	}
+	if len(names) != 2 {
+		t.Errorf("expected two distinct BackupSessions, got %v", names)
+	}
+
+	if _, err := r.Create(ctx, "paused", &uisrv.BackupTrigger{}, nil, &metav1.CreateOptions{}); !apierrors.IsConflict(err) {
+		t.Errorf("expected a conflict for a paused configuration, got %v", err)
+	}
+
+	r = NewBackupTriggerStorage(kc, denyResources("get/"+stashv1beta1.ResourcePluralBackupConfiguration))
+	if _, err := r.Create(ctx, "sample", &uisrv.BackupTrigger{}, nil, &metav1.CreateOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("expected forbidden without get on the BackupConfiguration, got %v", err)
+	}
+}
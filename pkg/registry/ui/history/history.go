@@ -0,0 +1,225 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/history"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+const defaultSummaryWindow = 30 * 24 * time.Hour
+
+type BackupHistoryStorage struct {
+	store     *history.Store
+	a         authorizer.Authorizer
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &BackupHistoryStorage{}
+	_ rest.Scoper                   = &BackupHistoryStorage{}
+	_ rest.Storage                  = &BackupHistoryStorage{}
+	_ rest.Creater                  = &BackupHistoryStorage{}
+	_ rest.SingularNameProvider     = &BackupHistoryStorage{}
+)
+
+func NewBackupHistoryStorage(store *history.Store, a authorizer.Authorizer) *BackupHistoryStorage {
+	return &BackupHistoryStorage{
+		store: store,
+		a:     a,
+		convertor: rest.NewDefaultTableConvertor(schema.GroupResource{
+			Group:    ui.GroupName,
+			Resource: uisrv.ResourceBackupHistories,
+		}),
+	}
+}
+
+func (r *BackupHistoryStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupHistory)
+}
+
+func (r *BackupHistoryStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindBackupHistory)
+}
+
+func (r *BackupHistoryStorage) NamespaceScoped() bool {
+	return false
+}
+
+func (r *BackupHistoryStorage) New() runtime.Object {
+	return &uisrv.BackupHistory{}
+}
+
+func (r *BackupHistoryStorage) Destroy() {}
+
+func (r *BackupHistoryStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BackupHistory)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupHistory, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, resource := range []string{stashv1beta1.ResourcePluralBackupConfiguration, stashv1beta1.ResourcePluralBackupSession} {
+		attrs := authorizer.AttributesRecord{
+			User:            user,
+			Verb:            "list",
+			Namespace:       in.Spec.Namespace,
+			APIGroup:        stashapi.GroupName,
+			Resource:        resource,
+			ResourceRequest: true,
+		}
+		decision, why, err := r.a.Authorize(ctx, attrs)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		if decision != authorizer.DecisionAllow {
+			return nil, apierrors.NewForbidden(schema.GroupResource{Group: stashapi.GroupName, Resource: resource}, "", errors.New(why))
+		}
+	}
+
+	if in.Spec.AsOf.IsZero() {
+		return nil, apierrors.NewBadRequest("asOf is required")
+	}
+	window := defaultSummaryWindow
+	if in.Spec.Window != nil {
+		if in.Spec.Window.Duration <= 0 {
+			return nil, apierrors.NewBadRequest("window must be positive")
+		}
+		window = in.Spec.Window.Duration
+	}
+	end := in.Spec.AsOf.Time
+	start := end.Add(-window)
+
+	result := in.DeepCopy()
+	result.CreationTimestamp = metav1.Now()
+	result.Status = uisrv.BackupHistoryStatus{
+		Start: metav1.NewTime(start),
+		End:   metav1.NewTime(end),
+	}
+
+	oldest, err := r.store.Oldest()
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if oldest != nil {
+		result.Status.OldestRecord = &metav1.Time{Time: *oldest}
+	}
+
+	overviews, err := r.store.OverviewsAsOf(end)
+	if err != nil && !errors.Is(err, history.ErrNoHistory) {
+		return nil, apierrors.NewInternalError(err)
+	}
+	for _, o := range overviews {
+		if in.Spec.Namespace != "" && o.Namespace != in.Spec.Namespace {
+			continue
+		}
+		result.Status.Overviews = append(result.Status.Overviews, uisrv.HistoricalBackupOverview{
+			Namespace: o.Namespace,
+			Name:      o.Name,
+			Overview:  o.Spec,
+			Phase:     o.Phase,
+		})
+	}
+
+	sessions, err := r.store.Sessions(start, end)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	result.Status.Summaries = summarizeSessions(sessions, in.Spec.Namespace)
+	return result, nil
+}
+
+func (r *BackupHistoryStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
+
+// summarizeSessions counts the session outcomes per invoker
+func summarizeSessions(sessions []history.Session, namespace string) []uisrv.BackupSessionSummary {
+	summaries := map[kmapi.TypedObjectReference]*uisrv.BackupSessionSummary{}
+	for _, s := range sessions {
+		if namespace != "" && s.Namespace != namespace {
+			continue
+		}
+		invoker := kmapi.TypedObjectReference{
+			APIGroup:  stashapi.GroupName,
+			Kind:      s.InvokerKind,
+			Namespace: s.Namespace,
+			Name:      s.InvokerName,
+		}
+		summary, ok := summaries[invoker]
+		if !ok {
+			summary = &uisrv.BackupSessionSummary{Invoker: invoker}
+			summaries[invoker] = summary
+		}
+		summary.Total++
+		switch s.Phase {
+		case stashv1beta1.BackupSessionSucceeded:
+			summary.Succeeded++
+			if summary.LastSucceeded == nil || s.Created.After(summary.LastSucceeded.Time) {
+				summary.LastSucceeded = &metav1.Time{Time: s.Created}
+			}
+		case stashv1beta1.BackupSessionFailed:
+			summary.Failed++
+		case stashv1beta1.BackupSessionSkipped:
+			summary.Skipped++
+		}
+	}
+
+	result := make([]uisrv.BackupSessionSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		x, y := result[i].Invoker, result[j].Invoker
+		if x.Namespace != y.Namespace {
+			return x.Namespace < y.Namespace
+		}
+		if x.Kind != y.Kind {
+			return x.Kind < y.Kind
+		}
+		return x.Name < y.Name
+	})
+	return result
+}
@@ -0,0 +1,243 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/history"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultLedgerWindow = 7 * 24 * time.Hour
+
+// BackupRunLedgerStorage implements the ledger subresource of BackupOverview
+type BackupRunLedgerStorage struct {
+	kc client.Client
+	// apiReader lists the Jobs and Events, which are not cached
+	apiReader client.Reader
+	// store is the recorded history. It is nil if the history is disabled.
+	store *history.Store
+	a     authorizer.Authorizer
+	gr    schema.GroupResource
+}
+
+var (
+	_ rest.Storage                  = &BackupRunLedgerStorage{}
+	_ rest.Getter                   = &BackupRunLedgerStorage{}
+	_ rest.NamedCreater             = &BackupRunLedgerStorage{}
+	_ rest.GroupVersionKindProvider = &BackupRunLedgerStorage{}
+)
+
+func NewBackupRunLedgerStorage(kc client.Client, apiReader client.Reader, store *history.Store, a authorizer.Authorizer) *BackupRunLedgerStorage {
+	return &BackupRunLedgerStorage{
+		kc:        kc,
+		apiReader: apiReader,
+		store:     store,
+		a:         a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1beta1.ResourcePluralBackupConfiguration,
+		},
+	}
+}
+
+func (r *BackupRunLedgerStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupRunLedger)
+}
+
+func (r *BackupRunLedgerStorage) New() runtime.Object {
+	return &uisrv.BackupRunLedger{}
+}
+
+func (r *BackupRunLedgerStorage) Destroy() {}
+
+// Get returns the ledger of the default time window
+func (r *BackupRunLedgerStorage) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	return r.ledger(ctx, name, &uisrv.BackupRunLedger{})
+}
+
+// Create returns the ledger of the time window given in the spec
+func (r *BackupRunLedgerStorage) Create(ctx context.Context, name string, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	in, ok := obj.(*uisrv.BackupRunLedger)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupRunLedger, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	return r.ledger(ctx, name, in)
+}
+
+func (r *BackupRunLedgerStorage) ledger(ctx context.Context, name string, in *uisrv.BackupRunLedger) (*uisrv.BackupRunLedger, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            user,
+		Verb:            "get",
+		Namespace:       ns,
+		APIGroup:        r.gr.Group,
+		Resource:        r.gr.Resource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, why, err := r.a.Authorize(ctx, attrs)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return nil, apierrors.NewForbidden(r.gr, name, errors.New(why))
+	}
+
+	window := defaultLedgerWindow
+	if in.Spec.Window != nil {
+		if in.Spec.Window.Duration <= 0 {
+			return nil, apierrors.NewBadRequest("window must be positive")
+		}
+		window = in.Spec.Window.Duration
+	}
+	now := time.Now()
+	end := now
+	if in.Spec.End != nil {
+		end = in.Spec.End.Time
+	}
+	start := end.Add(-window)
+
+	cfg := &stashv1beta1.BackupConfiguration{}
+	if err := r.kc.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, cfg); err != nil {
+		return nil, err
+	}
+	obs, err := r.observe(ctx, cfg, start, end)
+	if err != nil {
+		return nil, err
+	}
+	status, err := buildLedger(obs, start, end, now)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	result := in.DeepCopy()
+	result.Name = name
+	result.Namespace = ns
+	result.UID = cfg.UID
+	result.CreationTimestamp = cfg.CreationTimestamp
+	result.Status = status
+	return result, nil
+}
+
+// observe collects what is known to explain the missed runs of a configuration.
+// The BackupSessions pruned from the cluster are taken from the recorded
+// history and the Events, the same way as for the SLO reports.
+func (r *BackupRunLedgerStorage) observe(ctx context.Context, cfg *stashv1beta1.BackupConfiguration, start, end time.Time) (observations, error) {
+	obs := observations{cfg: cfg}
+	seen := map[types.UID]bool{}
+	add := func(s history.Session) {
+		if seen[s.UID] || s.InvokerKind != stashv1beta1.ResourceKindBackupConfiguration || s.InvokerName != cfg.Name {
+			return
+		}
+		seen[s.UID] = true
+		obs.sessions = append(obs.sessions, s)
+	}
+
+	var sessions stashv1beta1.BackupSessionList
+	if err := r.kc.List(ctx, &sessions, client.InNamespace(cfg.Namespace)); err != nil {
+		return obs, err
+	}
+	for i := range sessions.Items {
+		add(history.NewSession(&sessions.Items[i]))
+	}
+
+	if r.store != nil {
+		recorded, err := r.store.Sessions(start, end)
+		if err != nil {
+			return obs, apierrors.NewInternalError(err)
+		}
+		for _, s := range recorded {
+			if s.Namespace == cfg.Namespace {
+				add(s)
+			}
+		}
+		oldest, err := r.store.Oldest()
+		if err != nil {
+			return obs, apierrors.NewInternalError(err)
+		}
+		if oldest != nil {
+			obs.recordedSince = *oldest
+		}
+	}
+
+	var events core.EventList
+	if err := r.apiReader.List(ctx, &events, client.InNamespace(cfg.Namespace), client.MatchingFields{"involvedObject.kind": stashv1beta1.ResourceKindBackupSession}); err != nil {
+		// Events only fill in sessions pruned from the cluster
+		klog.Warningf("failed to list the Events of BackupSessions, reason: %v", err)
+	} else {
+		for i := range events.Items {
+			if s, ok := history.SessionFromEvent(&events.Items[i]); ok {
+				add(s)
+			}
+		}
+	}
+
+	cj, err := backups.BackupCronJob(ctx, r.kc, cfg)
+	if err != nil {
+		return obs, err
+	}
+	obs.cronJob = cj
+	if cj != nil {
+		// the Jobs only help to tell the cause of a missed run
+		if obs.jobRuns, err = backups.CronJobRuns(ctx, r.apiReader, cj); err != nil {
+			klog.Warningf("failed to list the Jobs of CronJob %s/%s, reason: %v", cj.Namespace, cj.Name, err)
+		}
+	}
+
+	if r.store != nil {
+		timeline, err := r.store.Timeline(types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.Name}, start, end)
+		if err != nil {
+			return obs, apierrors.NewInternalError(err)
+		}
+		obs.timeline = timeline
+	}
+	return obs, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ledger
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/history"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// maxRuns bounds the scheduled runs of a ledger
+	maxRuns = 10000
+	// pendingGrace is the time a BackupSession may take to show up after the fire time
+	pendingGrace = 5 * time.Minute
+)
+
+// observations is what is known about a BackupConfiguration to explain its missed runs
+type observations struct {
+	cfg *stashv1beta1.BackupConfiguration
+	// sessions are the BackupSessions of the configuration, including the ones
+	// pruned from the cluster that are known from the history or the Events
+	sessions []history.Session
+	// cronJob is nil if the CronJob does not exist
+	cronJob *batchv1.CronJob
+	// jobRuns are the scheduled times of the Jobs of the CronJob
+	jobRuns []time.Time
+	// timeline is the recorded history of the configuration, if any
+	timeline []history.Record
+	// recordedSince is the start of the recorded history, zero if there is none
+	recordedSince time.Time
+}
+
+// buildLedger matches the fire times of the schedule in [start, end) to the
+// BackupSessions. A session matches the first fire time before its creation
+// if no other session matched it yet. Unmatched fire times before the oldest
+// known session and the start of the recorded history are unknown, as their
+// sessions may have been pruned.
+func buildLedger(obs observations, start, end, now time.Time) (uisrv.BackupRunLedgerStatus, error) {
+	status := uisrv.BackupRunLedgerStatus{
+		Start: metav1.NewTime(start),
+		End:   metav1.NewTime(end),
+	}
+	sched, err := backups.ParseSchedule(obs.cfg.Spec.Schedule)
+	if err != nil {
+		return status, err
+	}
+	if obs.cfg.CreationTimestamp.After(start) {
+		start = obs.cfg.CreationTimestamp.Time
+	}
+
+	var fires []time.Time
+	for t := sched.Next(start.Add(-time.Second)); !t.IsZero() && t.Before(end); t = sched.Next(t) {
+		if len(fires) == maxRuns {
+			return status, fmt.Errorf("the window holds more than %d scheduled runs", maxRuns)
+		}
+		fires = append(fires, t)
+	}
+
+	knownSince := obs.recordedSince
+	sessions := make([]history.Session, 0, len(obs.sessions))
+	for _, s := range obs.sessions {
+		if knownSince.IsZero() || s.Created.Before(knownSince) {
+			knownSince = s.Created
+		}
+		if !s.Created.Before(start) && s.Created.Before(end) {
+			sessions = append(sessions, s)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Created.Before(sessions[j].Created) })
+
+	next := 0
+	for i, fire := range fires {
+		slotEnd := sched.Next(fire)
+		if i+1 < len(fires) {
+			slotEnd = fires[i+1]
+		}
+		// sessions created before the fire time were not created for it
+		for next < len(sessions) && sessions[next].Created.Before(fire) {
+			status.UnscheduledSessions = append(status.UnscheduledSessions, sessions[next].Name)
+			next++
+		}
+
+		run := uisrv.BackupRun{ScheduledTime: metav1.NewTime(fire)}
+		switch {
+		case next < len(sessions) && sessions[next].Created.Before(slotEnd):
+			s := sessions[next]
+			next++
+			run.State = uisrv.BackupRunMatched
+			run.Session = s.Name
+			run.SessionPhase = s.Phase
+			run.Created = &metav1.Time{Time: s.Created}
+			status.Matched++
+		case now.Sub(fire) < pendingGrace:
+			run.State = uisrv.BackupRunPending
+		case fire.Before(knownSince):
+			run.State = uisrv.BackupRunUnknown
+			run.Message = fmt.Sprintf("no BackupSession is known before %s, older ones may have been pruned", formatTime(knownSince))
+			status.Unknown++
+		default:
+			run.State = uisrv.BackupRunMissed
+			run.Cause, run.Message = missedCause(obs, fire, slotEnd)
+			status.Missed++
+		}
+		status.Runs = append(status.Runs, run)
+
+		// further sessions of the slot were triggered manually
+		for next < len(sessions) && sessions[next].Created.Before(slotEnd) {
+			status.UnscheduledSessions = append(status.UnscheduledSessions, sessions[next].Name)
+			next++
+		}
+	}
+	for ; next < len(sessions); next++ {
+		status.UnscheduledSessions = append(status.UnscheduledSessions, sessions[next].Name)
+	}
+	return status, nil
+}
+
+// missedCause tells the likely cause of a run scheduled at fire that got no BackupSession
+func missedCause(obs observations, fire, slotEnd time.Time) (uisrv.MissedRunCause, string) {
+	if cause, msg := pauseCause(obs, fire); cause != "" {
+		return cause, msg
+	}
+
+	cj := obs.cronJob
+	if cj == nil {
+		return uisrv.MissedRunCronJobMissing, "no CronJob exists for the BackupConfiguration"
+	}
+	if cj.CreationTimestamp.Time.After(fire) {
+		return uisrv.MissedRunCronJobMissing, fmt.Sprintf("the CronJob was only created at %s", formatTime(cj.CreationTimestamp.Time))
+	}
+	for _, t := range obs.jobRuns {
+		if !t.Before(fire) && t.Before(slotEnd) {
+			return uisrv.MissedRunOperatorDown, fmt.Sprintf("the CronJob fired at %s, but no BackupSession was created", formatTime(t))
+		}
+	}
+	if last := cj.Status.LastScheduleTime; last != nil && !last.Time.Before(fire) && last.Time.Before(slotEnd) {
+		return uisrv.MissedRunOperatorDown, fmt.Sprintf("the CronJob fired at %s, but no BackupSession was created", formatTime(last.Time))
+	}
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		return uisrv.MissedRunCronJobSuspended, "the CronJob is suspended"
+	}
+	if last := cj.Status.LastScheduleTime; last != nil && last.Time.Before(fire) {
+		return uisrv.MissedRunUnknown, fmt.Sprintf("the CronJob did not fire, it was last scheduled at %s", formatTime(last.Time))
+	}
+	return uisrv.MissedRunUnknown, "the CronJob did not fire"
+}
+
+// pauseCause tells whether the configuration was paused at a point in time,
+// according to the recorded history or the pause annotations. It returns an
+// empty cause if it was not paused, and Unknown if it is paused since a time
+// that is not known.
+func pauseCause(obs observations, t time.Time) (uisrv.MissedRunCause, string) {
+	var recorded *history.Record
+	for i := range obs.timeline {
+		if obs.timeline[i].Time.After(t) {
+			break
+		}
+		recorded = &obs.timeline[i]
+	}
+	if recorded != nil && recorded.Overview != nil {
+		if recorded.Overview.Spec.Status == uiapi.BackupStatusPaused {
+			return uisrv.MissedRunPaused, fmt.Sprintf("the BackupConfiguration was paused at %s", formatTime(recorded.Time))
+		}
+		return "", ""
+	}
+
+	if !obs.cfg.Spec.Paused {
+		return "", ""
+	}
+	at, err := time.Parse(time.RFC3339, obs.cfg.Annotations[uisrv.AnnotationPausedAt])
+	if err != nil {
+		// paused without this server, the run may have been missed before
+		return uisrv.MissedRunUnknown, "the BackupConfiguration is paused, but it is not known since when"
+	}
+	if at.After(t) {
+		return "", ""
+	}
+	return uisrv.MissedRunPaused, fmt.Sprintf("the BackupConfiguration is paused since %s", formatTime(at))
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
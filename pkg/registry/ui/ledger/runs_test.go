@@ -0,0 +1,180 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/history"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildLedger(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(h int, m int) time.Time {
+		return start.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	session := func(name string, created time.Time) history.Session {
+		return history.Session{Name: name, Created: created, Phase: stashv1beta1.BackupSessionSucceeded}
+	}
+	suspend := true
+
+	obs := observations{
+		cfg: &stashv1beta1.BackupConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "mysql", CreationTimestamp: metav1.NewTime(start.Add(-time.Hour))},
+			Spec:       stashv1beta1.BackupConfigurationSpec{Schedule: "0 * * * *"},
+		},
+		sessions: []history.Session{
+			session("mysql-0", hour(0, 0)),
+			session("mysql-manual", hour(0, 30)),
+			session("mysql-3", hour(3, 1)),
+		},
+		cronJob: &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(start.Add(-time.Hour))},
+			Spec:       batchv1.CronJobSpec{Suspend: &suspend},
+			Status:     batchv1.CronJobStatus{LastScheduleTime: &metav1.Time{Time: hour(4, 0)}},
+		},
+		jobRuns: []time.Time{hour(2, 0)},
+		timeline: []history.Record{
+			{Time: start, Overview: &history.Overview{Spec: uiapi.BackupOverviewSpec{Status: uiapi.BackupStatusActive}}},
+			{Time: hour(0, 45), Overview: &history.Overview{Spec: uiapi.BackupOverviewSpec{Status: uiapi.BackupStatusPaused}}},
+			{Time: hour(1, 30), Overview: &history.Overview{Spec: uiapi.BackupOverviewSpec{Status: uiapi.BackupStatusActive}}},
+		},
+	}
+
+	status, err := buildLedger(obs, start, hour(6, 0), hour(5, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		state uisrv.BackupRunState
+		cause uisrv.MissedRunCause
+	}{
+		{uisrv.BackupRunMatched, ""},
+		{uisrv.BackupRunMissed, uisrv.MissedRunPaused},
+		{uisrv.BackupRunMissed, uisrv.MissedRunOperatorDown},
+		{uisrv.BackupRunMatched, ""},
+		{uisrv.BackupRunMissed, uisrv.MissedRunOperatorDown},
+		{uisrv.BackupRunPending, ""},
+	}
+	if len(status.Runs) != len(expected) {
+		t.Fatalf("ledger has %d runs, expected %d", len(status.Runs), len(expected))
+	}
+	for i, e := range expected {
+		run := status.Runs[i]
+		if run.State != e.state || run.Cause != e.cause {
+			t.Errorf("run at %s is %s (%s), expected %s (%s)", run.ScheduledTime.Format(time.RFC3339), run.State, run.Cause, e.state, e.cause)
+		}
+	}
+	if status.Matched != 2 || status.Missed != 3 {
+		t.Errorf("matched %d and missed %d runs, expected 2 and 3", status.Matched, status.Missed)
+	}
+	if len(status.UnscheduledSessions) != 1 || status.UnscheduledSessions[0] != "mysql-manual" {
+		t.Errorf("unscheduled sessions = %v, expected [mysql-manual]", status.UnscheduledSessions)
+	}
+
+	// once the CronJob stops firing, the missed runs are blamed on the suspension
+	status, err = buildLedger(obs, hour(5, 0), hour(6, 0), hour(7, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Runs) != 1 || status.Runs[0].Cause != uisrv.MissedRunCronJobSuspended {
+		t.Errorf("runs = %+v, expected a run missed as the CronJob is suspended", status.Runs)
+	}
+}
+
+func TestBuildLedgerPrunedSessions(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+	obs := observations{
+		cfg: &stashv1beta1.BackupConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "mysql", CreationTimestamp: metav1.NewTime(start.Add(-time.Hour))},
+			Spec:       stashv1beta1.BackupConfigurationSpec{Schedule: "0 * * * *"},
+		},
+		// only the last session is left in the cluster, as backupHistoryLimit defaults to 1
+		sessions: []history.Session{{Name: "mysql-3", Created: hour(3), Phase: stashv1beta1.BackupSessionSucceeded}},
+	}
+
+	status, err := buildLedger(obs, start, hour(4), hour(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []uisrv.BackupRunState{uisrv.BackupRunUnknown, uisrv.BackupRunUnknown, uisrv.BackupRunUnknown, uisrv.BackupRunMatched}
+	if len(status.Runs) != len(expected) {
+		t.Fatalf("ledger has %d runs, expected %d", len(status.Runs), len(expected))
+	}
+	for i, e := range expected {
+		if status.Runs[i].State != e {
+			t.Errorf("run at %s is %s, expected %s", status.Runs[i].ScheduledTime.Format(time.RFC3339), status.Runs[i].State, e)
+		}
+	}
+	if status.Unknown != 3 || status.Missed != 0 {
+		t.Errorf("unknown %d and missed %d runs, expected 3 and 0", status.Unknown, status.Missed)
+	}
+
+	// the recorded history tells that the earlier runs were missed
+	obs.recordedSince = start.Add(-time.Hour)
+	status, err = buildLedger(obs, start, hour(4), hour(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Unknown != 0 || status.Missed != 3 {
+		t.Errorf("unknown %d and missed %d runs, expected 0 and 3", status.Unknown, status.Missed)
+	}
+}
+
+func TestBuildLedgerPausedSinceUnknown(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+	obs := observations{
+		cfg: &stashv1beta1.BackupConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "mysql", CreationTimestamp: metav1.NewTime(start.Add(-time.Hour))},
+			Spec:       stashv1beta1.BackupConfigurationSpec{Schedule: "0 * * * *", Paused: true},
+		},
+		recordedSince: start.Add(-time.Hour),
+	}
+
+	status, err := buildLedger(obs, start, hour(2), hour(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Runs) != 2 {
+		t.Fatalf("ledger has %d runs, expected 2", len(status.Runs))
+	}
+	for _, run := range status.Runs {
+		if run.Cause != uisrv.MissedRunUnknown {
+			t.Errorf("run at %s is missed as %s, expected %s", run.ScheduledTime.Format(time.RFC3339), run.Cause, uisrv.MissedRunUnknown)
+		}
+	}
+
+	// the paused-at annotation tells which runs were missed due to the pause
+	obs.cfg.Annotations = map[string]string{uisrv.AnnotationPausedAt: hour(1).Format(time.RFC3339)}
+	status, err = buildLedger(obs, start, hour(2), hour(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Runs) != 2 || status.Runs[0].Cause == uisrv.MissedRunPaused || status.Runs[1].Cause != uisrv.MissedRunPaused {
+		t.Errorf("runs = %+v, expected only the second run missed as paused", status.Runs)
+	}
+}
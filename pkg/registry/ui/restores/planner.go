@@ -0,0 +1,526 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apps "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	appcatalog "kmodules.xyz/custom-resources/apis/appcatalog/v1alpha1"
+	prober "kmodules.xyz/prober/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const pvcRestoreTask = "pvc-restore"
+
+// Snapshot is a snapshot recorded in the status of a BackupSession
+type Snapshot struct {
+	stashv1beta1.SnapshotStats
+	Host          string
+	Target        stashv1beta1.TargetRef
+	BackupSession string
+	CreatedAt     metav1.Time
+}
+
+// History is the snapshot history of a Repository as known from the
+// BackupSessions of the invokers that use it, oldest first.
+type History struct {
+	Snapshots  []Snapshot
+	Namespaces sets.Set[string]
+	Warnings   []string
+}
+
+// Planner resolves what a restore will do on behalf of a user
+type Planner struct {
+	kc client.Client
+	a  authorizer.Authorizer
+	u  user.Info
+}
+
+func NewPlanner(kc client.Client, a authorizer.Authorizer, u user.Info) *Planner {
+	return &Planner{kc: kc, a: a, u: u}
+}
+
+// Plan resolves the task, snapshots, hooks and timeout of a restore of repo
+// into spec.Target. Relative namespaces are resolved against ns.
+func (p *Planner) Plan(ctx context.Context, ns string, repo *stashv1alpha1.Repository, spec *uisrv.RestorePlanSpec) (*uisrv.RestorePlanStatus, error) {
+	status := &uisrv.RestorePlanStatus{
+		TimeOut: spec.TimeOut,
+	}
+	target := spec.Target
+	if target.Namespace == "" {
+		target.Namespace = ns
+	}
+
+	history, err := p.History(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	status.Warnings = append(status.Warnings, history.Warnings...)
+
+	task, warnings, err := p.ResolveTask(ctx, target, spec.Task)
+	if err != nil {
+		return nil, err
+	}
+	status.Task = task
+	status.Warnings = append(status.Warnings, warnings...)
+
+	hosts, warnings, err := p.targetHosts(ctx, target, spec.Rules, history)
+	if err != nil {
+		return nil, err
+	}
+	status.Warnings = append(status.Warnings, warnings...)
+
+	var total float64
+	for _, host := range hosts {
+		rule, found := matchRule(spec.Rules, host)
+		if !found {
+			status.Warnings = append(status.Warnings, fmt.Sprintf("no rule matches host %s, it will not be restored", host))
+			continue
+		}
+		h, size, warnings := history.resolveHost(host, target, rule)
+		status.Hosts = append(status.Hosts, h)
+		status.Warnings = append(status.Warnings, warnings...)
+		total += size
+	}
+	status.DataSize = shared.FormatSize(total)
+
+	if spec.Hooks != nil {
+		if spec.Hooks.PreRestore != nil {
+			status.Hooks = append(status.Hooks, newHook("preRestore", spec.Hooks.PreRestore, ""))
+		}
+		if spec.Hooks.PostRestore != nil && spec.Hooks.PostRestore.Handler != nil {
+			status.Hooks = append(status.Hooks, newHook("postRestore", spec.Hooks.PostRestore.Handler, spec.Hooks.PostRestore.ExecutionPolicy))
+		}
+	}
+
+	warnings, err = p.targetWarnings(ctx, target, history)
+	if err != nil {
+		return nil, err
+	}
+	status.Warnings = append(status.Warnings, warnings...)
+	return status, nil
+}
+
+// History collects the snapshots of repo from the BackupSessions of the
+// BackupConfigurations and BackupBatches that reference it. Namespaces where
+// the user is not allowed to list BackupSessions are skipped with a warning.
+func (p *Planner) History(ctx context.Context, repo *stashv1alpha1.Repository) (*History, error) {
+	history := &History{
+		Namespaces: sets.New[string](),
+	}
+
+	invokers := map[stashv1beta1.BackupInvokerRef]string{}
+	for _, ref := range repo.Status.References {
+		if ref.Kind != stashv1beta1.ResourceKindBackupConfiguration && ref.Kind != stashv1beta1.ResourceKindBackupBatch {
+			continue
+		}
+		ns := ref.Namespace
+		if ns == "" {
+			ns = repo.Namespace
+		}
+		invokers[stashv1beta1.BackupInvokerRef{APIGroup: stashapi.GroupName, Kind: ref.Kind, Name: ref.Name}] = ns
+	}
+	// older versions of Stash do not record references in the Repository status
+	cfgList := stashv1beta1.BackupConfigurationList{}
+	if err := p.kc.List(ctx, &cfgList, client.InNamespace(repo.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, cfg := range cfgList.Items {
+		if cfg.Spec.Repository.Name != repo.Name || (cfg.Spec.Repository.Namespace != "" && cfg.Spec.Repository.Namespace != repo.Namespace) {
+			continue
+		}
+		invokers[stashv1beta1.BackupInvokerRef{APIGroup: stashapi.GroupName, Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: cfg.Name}] = cfg.Namespace
+	}
+
+	gr := schema.GroupResource{Group: stashapi.GroupName, Resource: stashv1beta1.ResourcePluralBackupSession}
+	sessions := map[string][]stashv1beta1.BackupSession{}
+	for invoker, ns := range invokers {
+		history.Namespaces.Insert(ns)
+		items, found := sessions[ns]
+		if !found {
+			if err := shared.Authorize(ctx, p.a, p.u, "list", ns, gr, ""); err != nil {
+				if !apierrors.IsForbidden(err) {
+					return nil, err
+				}
+				history.Warnings = append(history.Warnings, fmt.Sprintf("not allowed to list BackupSessions in namespace %s, its snapshots are not considered", ns))
+				sessions[ns] = nil
+				continue
+			}
+			list := stashv1beta1.BackupSessionList{}
+			if err := p.kc.List(ctx, &list, client.InNamespace(ns)); err != nil {
+				return nil, err
+			}
+			items = list.Items
+			sessions[ns] = items
+		}
+		for _, s := range items {
+			if s.Spec.Invoker.Kind != invoker.Kind || s.Spec.Invoker.Name != invoker.Name {
+				continue
+			}
+			for _, t := range s.Status.Targets {
+				for _, stats := range t.Stats {
+					if stats.Phase != stashv1beta1.HostBackupSucceeded {
+						continue
+					}
+					for _, snap := range stats.Snapshots {
+						history.Snapshots = append(history.Snapshots, Snapshot{
+							SnapshotStats: snap,
+							Host:          stats.Hostname,
+							Target:        t.Ref,
+							BackupSession: s.Name,
+							CreatedAt:     s.CreationTimestamp,
+						})
+					}
+				}
+			}
+		}
+	}
+	sort.SliceStable(history.Snapshots, func(i, j int) bool {
+		return history.Snapshots[i].CreatedAt.Before(&history.Snapshots[j].CreatedAt)
+	})
+	return history, nil
+}
+
+// ResolveTask picks the restore Task the same way Stash does. Workloads are
+// restored by the init-container and do not use a Task.
+func (p *Planner) ResolveTask(ctx context.Context, target stashv1beta1.TargetRef, ref stashv1beta1.TaskRef) (*uisrv.RestorePlanTask, []string, error) {
+	var warnings []string
+
+	if ref.Name == "" {
+		switch target.Kind {
+		case appcatalog.ResourceKindApp:
+			appGR := schema.GroupResource{Group: appcatalog.SchemeGroupVersion.Group, Resource: appcatalog.ResourceApps}
+			if err := shared.Authorize(ctx, p.a, p.u, "get", target.Namespace, appGR, target.Name); err != nil {
+				if !apierrors.IsForbidden(err) {
+					return nil, nil, err
+				}
+				return nil, []string{fmt.Sprintf("not allowed to get AppBinding %s/%s, unable to resolve restore task", target.Namespace, target.Name)}, nil
+			}
+			app := &appcatalog.AppBinding{}
+			if err := p.kc.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: target.Name}, app); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, nil, err
+				}
+				return nil, []string{fmt.Sprintf("AppBinding %s/%s not found, unable to resolve restore task", target.Namespace, target.Name)}, nil
+			}
+			if app.Spec.Parameters != nil {
+				var addon appcatalog.StashAddon
+				if err := json.Unmarshal(app.Spec.Parameters.Raw, &addon); err == nil {
+					ref.Name = addon.Stash.Addon.RestoreTask.Name
+					for _, param := range addon.Stash.Addon.RestoreTask.Params {
+						ref.Params = append(ref.Params, stashv1beta1.Param{Name: param.Name, Value: param.Value})
+					}
+				}
+			}
+			if ref.Name == "" {
+				return nil, []string{fmt.Sprintf("AppBinding %s/%s does not specify a Stash restore task", target.Namespace, target.Name)}, nil
+			}
+		case "PersistentVolumeClaim":
+			ref.Name = pvcRestoreTask
+		default:
+			return nil, nil, nil
+		}
+	}
+
+	task := &uisrv.RestorePlanTask{
+		Name:   ref.Name,
+		Params: ref.Params,
+	}
+	// the steps are only shown to users that may read the Task
+	taskGR := schema.GroupResource{Group: stashapi.GroupName, Resource: stashv1beta1.ResourcePluralTask}
+	if err := shared.Authorize(ctx, p.a, p.u, "get", "", taskGR, ref.Name); err != nil {
+		if !apierrors.IsForbidden(err) {
+			return nil, nil, err
+		}
+		return task, append(warnings, fmt.Sprintf("not allowed to get Task %s, its steps are unknown", ref.Name)), nil
+	}
+	obj := &stashv1beta1.Task{}
+	if err := p.kc.Get(ctx, client.ObjectKey{Name: ref.Name}, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, nil, err
+		}
+		warnings = append(warnings, fmt.Sprintf("Task %s not found", ref.Name))
+	} else {
+		for _, step := range obj.Spec.Steps {
+			task.Steps = append(task.Steps, step.Name)
+		}
+	}
+	return task, warnings, nil
+}
+
+func (p *Planner) targetHosts(ctx context.Context, target stashv1beta1.TargetRef, rules []stashv1beta1.Rule, history *History) ([]string, []string, error) {
+	var warnings []string
+	hosts := sets.New[string]()
+	for _, rule := range rules {
+		hosts.Insert(rule.TargetHosts...)
+	}
+
+	switch target.Kind {
+	case "StatefulSet":
+		sts := &apps.StatefulSet{}
+		err := p.get(ctx, target, "statefulsets", sts)
+		if err == nil {
+			replicas := int32(1)
+			if sts.Spec.Replicas != nil {
+				replicas = *sts.Spec.Replicas
+			}
+			for i := int32(0); i < replicas; i++ {
+				hosts.Insert(fmt.Sprintf("host-%d", i))
+			}
+			break
+		}
+		if !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+			return nil, nil, err
+		}
+		for _, s := range history.forTarget(target) {
+			hosts.Insert(s.Host)
+		}
+	case "DaemonSet":
+		// hosts are node names, use the ones known from the backups
+		for _, s := range history.forTarget(target) {
+			hosts.Insert(s.Host)
+		}
+	default:
+		hosts.Insert("host-0")
+	}
+
+	if hosts.Len() == 0 {
+		warnings = append(warnings, fmt.Sprintf("unable to determine the hosts of %s %s/%s", target.Kind, target.Namespace, target.Name))
+	}
+	return sets.List(hosts), warnings, nil
+}
+
+func (p *Planner) targetWarnings(ctx context.Context, target stashv1beta1.TargetRef, history *History) ([]string, error) {
+	var warnings []string
+	if history.Namespaces.Len() > 0 && !history.Namespaces.Has(target.Namespace) {
+		warnings = append(warnings, fmt.Sprintf("backups were taken in namespace %s, restoring into a different namespace %s",
+			strings.Join(sets.List(history.Namespaces), ","), target.Namespace))
+	}
+
+	var running int32
+	var err error
+	switch target.Kind {
+	case "Deployment":
+		obj := &apps.Deployment{}
+		if err = p.get(ctx, target, "deployments", obj); err == nil {
+			running = obj.Status.ReadyReplicas
+		}
+	case "StatefulSet":
+		obj := &apps.StatefulSet{}
+		if err = p.get(ctx, target, "statefulsets", obj); err == nil {
+			running = obj.Status.ReadyReplicas
+		}
+	case "DaemonSet":
+		obj := &apps.DaemonSet{}
+		if err = p.get(ctx, target, "daemonsets", obj); err == nil {
+			running = obj.Status.NumberReady
+		}
+	case "ReplicaSet":
+		obj := &apps.ReplicaSet{}
+		if err = p.get(ctx, target, "replicasets", obj); err == nil {
+			running = obj.Status.ReadyReplicas
+		}
+	default:
+		return warnings, nil
+	}
+	switch {
+	case apierrors.IsNotFound(err):
+		warnings = append(warnings, fmt.Sprintf("target %s %s/%s not found", target.Kind, target.Namespace, target.Name))
+	case apierrors.IsForbidden(err):
+		warnings = append(warnings, fmt.Sprintf("not allowed to get target %s %s/%s", target.Kind, target.Namespace, target.Name))
+	case err != nil:
+		return nil, err
+	case running > 0:
+		warnings = append(warnings, fmt.Sprintf("target %s %s/%s has %d running pod(s), they will be restarted and their data overwritten",
+			target.Kind, target.Namespace, target.Name, running))
+	}
+	return warnings, nil
+}
+
+func (p *Planner) get(ctx context.Context, target stashv1beta1.TargetRef, resource string, obj client.Object) error {
+	gr := schema.GroupResource{Group: apps.GroupName, Resource: resource}
+	if err := shared.Authorize(ctx, p.a, p.u, "get", target.Namespace, gr, target.Name); err != nil {
+		return err
+	}
+	return p.kc.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: target.Name}, obj)
+}
+
+// Find returns the snapshot of the history that name refers to, or nil if
+// there is none. See snapshotNameMatches for the accepted names.
+func (h *History) Find(name string) *Snapshot {
+	for i := range h.Snapshots {
+		if snapshotNameMatches(h.Snapshots[i].Name, name) {
+			return &h.Snapshots[i]
+		}
+	}
+	return nil
+}
+
+// forTarget returns the snapshots taken from target. If the Repository was
+// never used to back up target, all snapshots are returned so that a backup
+// can be restored into a different workload.
+func (h *History) forTarget(target stashv1beta1.TargetRef) []Snapshot {
+	var out []Snapshot
+	for _, s := range h.Snapshots {
+		if s.Target.Kind == target.Kind && s.Target.Name == target.Name {
+			out = append(out, s)
+		}
+	}
+	if len(out) == 0 {
+		return h.Snapshots
+	}
+	return out
+}
+
+func (h *History) resolveHost(host string, target stashv1beta1.TargetRef, rule stashv1beta1.Rule) (uisrv.RestorePlanHost, float64, []string) {
+	var warnings []string
+	result := uisrv.RestorePlanHost{
+		TargetHost: host,
+		SourceHost: rule.SourceHost,
+		Include:    rule.Include,
+		Exclude:    rule.Exclude,
+	}
+	if result.SourceHost == "" {
+		result.SourceHost = host
+	}
+
+	var hostSnapshots []Snapshot
+	for _, s := range h.forTarget(target) {
+		if s.Host == result.SourceHost {
+			hostSnapshots = append(hostSnapshots, s)
+		}
+	}
+
+	var picked []Snapshot
+	if len(rule.Snapshots) > 0 {
+		for _, name := range rule.Snapshots {
+			matches := func(s Snapshot) bool { return snapshotNameMatches(s.Name, name) }
+			idx := slices.IndexFunc(hostSnapshots, matches)
+			if idx < 0 {
+				if slices.ContainsFunc(h.Snapshots, matches) {
+					warnings = append(warnings, fmt.Sprintf("snapshot %s was not taken from host %s of the target, its size is unknown", name, result.SourceHost))
+				} else {
+					warnings = append(warnings, fmt.Sprintf("snapshot %s is not found in the BackupSession history, its size is unknown", name))
+				}
+				picked = append(picked, Snapshot{SnapshotStats: stashv1beta1.SnapshotStats{Name: name}})
+				continue
+			}
+			picked = append(picked, hostSnapshots[idx])
+		}
+	} else {
+		var candidates []Snapshot
+		for _, s := range hostSnapshots {
+			if len(rule.Paths) == 0 || slices.Contains(rule.Paths, s.Path) {
+				candidates = append(candidates, s)
+			}
+		}
+		// latest snapshot of every path
+		seen := sets.New[string]()
+		for i := len(candidates) - 1; i >= 0; i-- {
+			if seen.Has(candidates[i].Path) {
+				continue
+			}
+			seen.Insert(candidates[i].Path)
+			picked = append([]Snapshot{candidates[i]}, picked...)
+		}
+		if len(picked) == 0 {
+			warnings = append(warnings, fmt.Sprintf("no snapshot of host %s found in the BackupSession history", result.SourceHost))
+		}
+	}
+
+	var size float64
+	for _, s := range picked {
+		snap := uisrv.RestorePlanSnapshot{
+			Name:          s.Name,
+			Path:          s.Path,
+			TotalSize:     s.TotalSize,
+			BackupSession: s.BackupSession,
+		}
+		if !s.CreatedAt.IsZero() {
+			snap.CreatedAt = s.CreatedAt.DeepCopy()
+		}
+		if v, err := shared.ParseSize(s.TotalSize); err == nil {
+			size += v
+		}
+		result.Snapshots = append(result.Snapshots, snap)
+	}
+	result.DataSize = shared.FormatSize(size)
+	return result, size, warnings
+}
+
+// snapshotNameMatches tells whether name refers to the snapshot id recorded in a
+// BackupSession. Restore rules may name snapshots as <repository>-<id>, the
+// way the Snapshot API lists them.
+func snapshotNameMatches(id, name string) bool {
+	return id != "" && (id == name || strings.HasSuffix(name, "-"+id))
+}
+
+// matchRule returns the first rule that applies to host, the same order
+// Stash uses. A restore without rules restores the latest snapshots of
+// every host.
+func matchRule(rules []stashv1beta1.Rule, host string) (stashv1beta1.Rule, bool) {
+	if len(rules) == 0 {
+		return stashv1beta1.Rule{}, true
+	}
+	for _, rule := range rules {
+		if len(rule.TargetHosts) == 0 || slices.Contains(rule.TargetHosts, host) {
+			return rule, true
+		}
+	}
+	return stashv1beta1.Rule{}, false
+}
+
+func newHook(name string, h *prober.Handler, policy stashv1beta1.HookExecutionPolicy) uisrv.RestorePlanHook {
+	return uisrv.RestorePlanHook{
+		Name:            name,
+		Action:          describeHandler(h),
+		ContainerName:   h.ContainerName,
+		ExecutionPolicy: policy,
+	}
+}
+
+func describeHandler(h *prober.Handler) string {
+	switch {
+	case h.Exec != nil:
+		return "exec: " + strings.Join(h.Exec.Command, " ")
+	case h.HTTPGet != nil:
+		return fmt.Sprintf("httpGet: %s:%s%s", h.HTTPGet.Host, h.HTTPGet.Port.String(), h.HTTPGet.Path)
+	case h.HTTPPost != nil:
+		return fmt.Sprintf("httpPost: %s:%s%s", h.HTTPPost.Host, h.HTTPPost.Port.String(), h.HTTPPost.Path)
+	case h.TCPSocket != nil:
+		return fmt.Sprintf("tcpSocket: %s:%s", h.TCPSocket.Host, h.TCPSocket.Port.String())
+	}
+	return "none"
+}
@@ -0,0 +1,219 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restores
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/utils/ptr"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var t0 = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+// allowExcept denies the given verb on BackupSessions in the namespaces and allows everything else
+func allowExcept(verb string, namespaces ...string) authorizer.Authorizer {
+	return authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		for _, ns := range namespaces {
+			if a.GetVerb() == verb && a.GetNamespace() == ns && a.GetResource() == stashv1beta1.ResourcePluralBackupSession {
+				return authorizer.DecisionDeny, "denied", nil
+			}
+		}
+		return authorizer.DecisionAllow, "", nil
+	})
+}
+
+func backupSession(name string, created time.Time, target stashv1beta1.TargetRef, stats ...stashv1beta1.HostBackupStats) *stashv1beta1.BackupSession {
+	return &stashv1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo", CreationTimestamp: metav1.NewTime(created)},
+		Spec: stashv1beta1.BackupSessionSpec{
+			Invoker: stashv1beta1.BackupInvokerRef{APIGroup: stashapi.GroupName, Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: "sts-backup"},
+		},
+		Status: stashv1beta1.BackupSessionStatus{
+			Targets: []stashv1beta1.BackupTargetStatus{{Ref: target, Stats: stats}},
+		},
+	}
+}
+
+func hostStats(host string, snapshots ...stashv1beta1.SnapshotStats) stashv1beta1.HostBackupStats {
+	return stashv1beta1.HostBackupStats{Hostname: host, Phase: stashv1beta1.HostBackupSucceeded, Snapshots: snapshots}
+}
+
+func planFixture(t *testing.T) (client.Client, *stashv1alpha1.Repository, stashv1beta1.TargetRef) {
+	target := stashv1beta1.TargetRef{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", Namespace: "demo"}
+	repo := &stashv1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "demo"}}
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "sts-backup", Namespace: "demo"},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			Repository: kmapi.ObjectReference{Name: "repo"},
+		},
+	}
+	sts := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "demo"},
+		Spec:       apps.StatefulSetSpec{Replicas: ptr.To(int32(2))},
+	}
+	kc := testclient.New(t, repo, cfg, sts,
+		backupSession("sts-backup-1", t0, target,
+			hostStats("host-0", stashv1beta1.SnapshotStats{Name: "aaa0", Path: "/data", TotalSize: "1 MiB"}),
+			hostStats("host-1", stashv1beta1.SnapshotStats{Name: "aaa1", Path: "/data", TotalSize: "1 MiB"}),
+		),
+		backupSession("sts-backup-2", t0.Add(time.Hour), target,
+			hostStats("host-0", stashv1beta1.SnapshotStats{Name: "bbb0", Path: "/data", TotalSize: "2 MiB"}),
+			hostStats("host-1", stashv1beta1.SnapshotStats{Name: "bbb1", Path: "/data", TotalSize: "2 MiB"}),
+		),
+	)
+	return kc, repo, target
+}
+
+func TestPlanLatestSnapshots(t *testing.T) {
+	kc, repo, target := planFixture(t)
+	p := NewPlanner(kc, allowExcept(""), &user.DefaultInfo{Name: "alice"})
+
+	status, err := p.Plan(context.TODO(), "demo", repo, &uisrv.RestorePlanSpec{Target: target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, h := range status.Hosts {
+		for _, s := range h.Snapshots {
+			got = append(got, h.TargetHost+":"+s.Name)
+		}
+	}
+	if want := []string{"host-0:bbb0", "host-1:bbb1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshots = %v, want %v", got, want)
+	}
+	if status.DataSize != "4.000 MiB" {
+		t.Errorf("data size = %s, want 4.000 MiB", status.DataSize)
+	}
+}
+
+func TestPlanNamedSnapshotOfAnotherHost(t *testing.T) {
+	kc, repo, target := planFixture(t)
+	p := NewPlanner(kc, allowExcept(""), &user.DefaultInfo{Name: "alice"})
+
+	spec := &uisrv.RestorePlanSpec{
+		Target: target,
+		Rules: []stashv1beta1.Rule{
+			{TargetHosts: []string{"host-0"}, Snapshots: []string{"repo-aaa0"}},
+			{TargetHosts: []string{"host-1"}, Snapshots: []string{"repo-aaa0"}},
+		},
+	}
+	status, err := p.Plan(context.TODO(), "demo", repo, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Hosts) != 2 {
+		t.Fatalf("unexpected hosts %+v", status.Hosts)
+	}
+	if s := status.Hosts[0].Snapshots[0]; s.BackupSession != "sts-backup-1" || s.TotalSize != "1 MiB" {
+		t.Errorf("host-0 resolved to %+v", s)
+	}
+	if s := status.Hosts[1].Snapshots[0]; s.BackupSession != "" {
+		t.Errorf("host-1 must not resolve to the snapshot of host-0, got %+v", s)
+	}
+	if !containsWarning(status.Warnings, "snapshot repo-aaa0 was not taken from host host-1") {
+		t.Errorf("missing warning in %q", status.Warnings)
+	}
+}
+
+func TestHistorySkipsForbiddenNamespaces(t *testing.T) {
+	kc, repo, _ := planFixture(t)
+	p := NewPlanner(kc, allowExcept("list", "demo"), &user.DefaultInfo{Name: "alice"})
+
+	history, err := p.History(context.TODO(), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Snapshots) != 0 {
+		t.Errorf("expected no snapshots, got %d", len(history.Snapshots))
+	}
+	if !containsWarning(history.Warnings, "not allowed to list BackupSessions in namespace demo") {
+		t.Errorf("missing warning in %q", history.Warnings)
+	}
+}
+
+func TestMatchRule(t *testing.T) {
+	rules := []stashv1beta1.Rule{
+		{TargetHosts: []string{"host-1"}, SourceHost: "host-0"},
+		{SourceHost: "host-2"},
+	}
+	if r, ok := matchRule(rules, "host-1"); !ok || r.SourceHost != "host-0" {
+		t.Errorf("host-1 matched %+v", r)
+	}
+	if r, ok := matchRule(rules, "host-3"); !ok || r.SourceHost != "host-2" {
+		t.Errorf("host-3 matched %+v", r)
+	}
+	if _, ok := matchRule(rules[:1], "host-3"); ok {
+		t.Error("host-3 must not match")
+	}
+}
+
+func containsWarning(warnings []string, prefix string) bool {
+	for _, w := range warnings {
+		if strings.HasPrefix(w, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestResolveTaskForbidden(t *testing.T) {
+	deny := func(resource string) authorizer.Authorizer {
+		return authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+			if a.GetResource() == resource {
+				return authorizer.DecisionDeny, "denied", nil
+			}
+			return authorizer.DecisionAllow, "", nil
+		})
+	}
+	kc := testclient.New(t)
+	u := &user.DefaultInfo{Name: "alice"}
+
+	// whether the AppBinding exists is not revealed
+	app := stashv1beta1.TargetRef{APIVersion: "appcatalog.appscode.com/v1alpha1", Kind: "AppBinding", Name: "db", Namespace: "demo"}
+	task, warnings, err := NewPlanner(kc, deny("appbindings"), u).ResolveTask(context.TODO(), app, stashv1beta1.TaskRef{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task != nil || len(warnings) != 1 || !strings.HasPrefix(warnings[0], "not allowed to get AppBinding demo/db") {
+		t.Errorf("task = %v, warnings = %v, expected a warning that the AppBinding may not be read", task, warnings)
+	}
+
+	pvc := stashv1beta1.TargetRef{APIVersion: "v1", Kind: "PersistentVolumeClaim", Name: "data", Namespace: "demo"}
+	task, warnings, err = NewPlanner(kc, deny("tasks"), u).ResolveTask(context.TODO(), pvc, stashv1beta1.TaskRef{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task == nil || task.Name != pvcRestoreTask || len(warnings) != 1 || !strings.HasPrefix(warnings[0], "not allowed to get Task") {
+		t.Errorf("task = %v, warnings = %v, expected %s without steps", task, warnings, pvcRestoreTask)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restores
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type RestorePlanStorage struct {
+	kc        client.Client
+	a         authorizer.Authorizer
+	gr        schema.GroupResource
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &RestorePlanStorage{}
+	_ rest.Scoper                   = &RestorePlanStorage{}
+	_ rest.Storage                  = &RestorePlanStorage{}
+	_ rest.Creater                  = &RestorePlanStorage{}
+	_ rest.SingularNameProvider     = &RestorePlanStorage{}
+)
+
+func NewRestorePlanStorage(kc client.Client, a authorizer.Authorizer) *RestorePlanStorage {
+	return &RestorePlanStorage{
+		kc: kc,
+		a:  a,
+		gr: schema.GroupResource{
+			Group:    stashapi.GroupName,
+			Resource: stashv1alpha1.ResourcePluralRepository,
+		},
+		convertor: rest.NewDefaultTableConvertor(schema.GroupResource{
+			Group:    ui.GroupName,
+			Resource: uisrv.ResourceRestorePlans,
+		}),
+	}
+}
+
+func (r *RestorePlanStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindRestorePlan)
+}
+
+func (r *RestorePlanStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindRestorePlan)
+}
+
+func (r *RestorePlanStorage) NamespaceScoped() bool {
+	return true
+}
+
+func (r *RestorePlanStorage) New() runtime.Object {
+	return &uisrv.RestorePlan{}
+}
+
+func (r *RestorePlanStorage) Destroy() {}
+
+func (r *RestorePlanStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	u, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.RestorePlan)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindRestorePlan, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	if in.Spec.Repository.Name == "" {
+		return nil, apierrors.NewBadRequest("missing repository name")
+	}
+	if in.Spec.Target.Kind == "" || in.Spec.Target.Name == "" {
+		return nil, apierrors.NewBadRequest("missing target kind or name")
+	}
+
+	repoKey := client.ObjectKey{Name: in.Spec.Repository.Name, Namespace: in.Spec.Repository.Namespace}
+	if repoKey.Namespace == "" {
+		repoKey.Namespace = ns
+	}
+	if err := shared.Authorize(ctx, r.a, u, "get", repoKey.Namespace, r.gr, repoKey.Name); err != nil {
+		return nil, err
+	}
+
+	repo := &stashv1alpha1.Repository{}
+	if err := r.kc.Get(ctx, repoKey, repo); err != nil {
+		return nil, fmt.Errorf("failed to get Repository, reason: %v", err)
+	}
+
+	result := in.DeepCopy()
+	result.Namespace = ns
+	result.CreationTimestamp = metav1.Now()
+	status, err := NewPlanner(r.kc, r.a, u).Plan(ctx, ns, repo, &in.Spec)
+	if err != nil {
+		return nil, err
+	}
+	result.Status = *status
+	return result, nil
+}
+
+func (r *RestorePlanStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slo
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/history"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxExpectedRuns bounds the cron iteration for very frequent schedules
+const maxExpectedRuns = 1_000_000
+
+// outcome is a BackupSession of a BackupConfiguration, from whichever source it was found in
+type outcome struct {
+	uid     types.UID
+	invoker types.NamespacedName
+	created time.Time
+	phase   stashv1beta1.BackupSessionPhase
+}
+
+// outcomes merges the sessions found in the cluster, in the history and in
+// the Events. A session found in more than one source is taken from the
+// first one, as the cluster is authoritative and the Events are the least
+// precise.
+type outcomes struct {
+	byUID map[types.UID]outcome
+}
+
+func newOutcomes() *outcomes {
+	return &outcomes{byUID: map[types.UID]outcome{}}
+}
+
+func (o *outcomes) add(oc outcome) {
+	if _, ok := o.byUID[oc.uid]; !ok {
+		o.byUID[oc.uid] = oc
+	}
+}
+
+func (o *outcomes) addSessions(sessions []stashv1beta1.BackupSession) {
+	for _, s := range sessions {
+		if s.Spec.Invoker.Kind != stashv1beta1.ResourceKindBackupConfiguration {
+			continue
+		}
+		o.add(outcome{
+			uid:     s.UID,
+			invoker: types.NamespacedName{Namespace: s.Namespace, Name: s.Spec.Invoker.Name},
+			created: s.CreationTimestamp.Time,
+			phase:   s.Status.Phase,
+		})
+	}
+}
+
+func (o *outcomes) addHistory(sessions []history.Session) {
+	for _, s := range sessions {
+		if s.InvokerKind != stashv1beta1.ResourceKindBackupConfiguration {
+			continue
+		}
+		o.add(outcome{
+			uid:     s.UID,
+			invoker: types.NamespacedName{Namespace: s.Namespace, Name: s.InvokerName},
+			created: s.Created,
+			phase:   s.Phase,
+		})
+	}
+}
+
+// addEvents adds the sessions known from the Events recorded on them
+func (o *outcomes) addEvents(events []core.Event) {
+	for i := range events {
+		if s, ok := history.SessionFromEvent(&events[i]); ok {
+			o.addHistory([]history.Session{s})
+		}
+	}
+}
+
+// byInvoker returns the outcomes created in [start, end) per invoker, sorted by creation time
+func (o *outcomes) byInvoker(start, end time.Time) map[types.NamespacedName][]outcome {
+	result := map[types.NamespacedName][]outcome{}
+	for _, oc := range o.byUID {
+		if oc.created.Before(start) || !oc.created.Before(end) {
+			continue
+		}
+		result[oc.invoker] = append(result[oc.invoker], oc)
+	}
+	for _, list := range result {
+		sort.Slice(list, func(i, j int) bool { return list[i].created.Before(list[j].created) })
+	}
+	return result
+}
+
+// expectedRuns counts the scheduled runs of a BackupConfiguration in [start,
+// end). Runs after the configuration was paused through this server are not
+// expected.
+func expectedRuns(cfg *stashv1beta1.BackupConfiguration, start, end time.Time) (int64, error) {
+	sched, err := backups.ParseSchedule(cfg.Spec.Schedule)
+	if err != nil {
+		return 0, err
+	}
+	if cfg.Spec.Paused {
+		if t, err := time.Parse(time.RFC3339, cfg.Annotations[uisrv.AnnotationPausedAt]); err == nil && t.Before(end) {
+			end = t
+		}
+	}
+	var n int64
+	for t := sched.Next(start.Add(-time.Second)); !t.IsZero() && t.Before(end) && n < maxExpectedRuns; t = sched.Next(t) {
+		n++
+	}
+	return n, nil
+}
+
+// computeSLO computes the service level of a BackupConfiguration in [start, end)
+func computeSLO(cfg *stashv1beta1.BackupConfiguration, sessions []outcome, start, end time.Time) (uisrv.BackupSLO, error) {
+	slo := uisrv.BackupSLO{
+		Namespace: cfg.Namespace,
+		Name:      cfg.Name,
+	}
+	if cfg.CreationTimestamp.After(start) {
+		start = cfg.CreationTimestamp.Time
+	}
+	if !start.Before(end) {
+		return slo, nil
+	}
+
+	expected, err := expectedRuns(cfg, start, end)
+	if err != nil {
+		return slo, err
+	}
+	slo.ExpectedRuns = expected
+
+	var successes []time.Time
+	for _, s := range sessions {
+		slo.Created++
+		switch s.phase {
+		case stashv1beta1.BackupSessionSucceeded:
+			slo.Succeeded++
+			successes = append(successes, s.created)
+		case stashv1beta1.BackupSessionFailed:
+			slo.Failed++
+		case stashv1beta1.BackupSessionSkipped:
+			slo.Skipped++
+		}
+	}
+	finish(&slo, end.Sub(start))
+
+	gap, gapStart := longestGap(successes, start, end)
+	slo.LongestGap = &metav1.Duration{Duration: gap}
+	slo.LongestGapStart = &metav1.Time{Time: gapStart}
+	return slo, nil
+}
+
+// finish computes the success rate and the mean time between failures from the counts
+func finish(slo *uisrv.BackupSLO, observed time.Duration) {
+	runs := slo.ExpectedRuns
+	if completed := slo.Succeeded + slo.Failed + slo.Skipped; completed > runs {
+		runs = completed
+	}
+	if runs > 0 {
+		rate := math.Round(10000*float64(slo.Succeeded)/float64(runs)) / 100
+		slo.SuccessRate = &rate
+	}
+	if slo.Failed > 0 {
+		slo.MeanTimeBetweenFailures = &metav1.Duration{Duration: (observed / time.Duration(slo.Failed)).Truncate(time.Second)}
+	}
+}
+
+// longestGap returns the longest time in [start, end) without a success
+func longestGap(successes []time.Time, start, end time.Time) (time.Duration, time.Time) {
+	var gap time.Duration
+	gapStart := start
+	prev := start
+	for _, t := range append(successes, end) {
+		if d := t.Sub(prev); d > gap {
+			gap, gapStart = d, prev
+		}
+		prev = t
+	}
+	return gap, gapStart
+}
+
+// namespaceSLOs aggregates the service levels of the configurations per namespace
+func namespaceSLOs(configs []uisrv.BackupSLO, observed time.Duration) []uisrv.BackupSLO {
+	byNamespace := map[string]*uisrv.BackupSLO{}
+	var namespaces []string
+	for _, c := range configs {
+		ns, ok := byNamespace[c.Namespace]
+		if !ok {
+			ns = &uisrv.BackupSLO{Namespace: c.Namespace}
+			byNamespace[c.Namespace] = ns
+			namespaces = append(namespaces, c.Namespace)
+		}
+		ns.ExpectedRuns += c.ExpectedRuns
+		ns.Created += c.Created
+		ns.Succeeded += c.Succeeded
+		ns.Failed += c.Failed
+		ns.Skipped += c.Skipped
+		if c.LongestGap != nil && (ns.LongestGap == nil || c.LongestGap.Duration > ns.LongestGap.Duration) {
+			ns.LongestGap = c.LongestGap.DeepCopy()
+			ns.LongestGapStart = c.LongestGapStart.DeepCopy()
+		}
+	}
+
+	sort.Strings(namespaces)
+	result := make([]uisrv.BackupSLO, 0, len(namespaces))
+	for _, name := range namespaces {
+		ns := byNamespace[name]
+		finish(ns, observed)
+		result = append(result, *ns)
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slo
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/ui-server/pkg/history"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestComputeSLO(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10 * 24 * time.Hour)
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "demo",
+			Name:              "mysql",
+			CreationTimestamp: metav1.NewTime(start.Add(-time.Hour)),
+		},
+		Spec: stashv1beta1.BackupConfigurationSpec{Schedule: "0 1 * * *"},
+	}
+	day := func(d int) time.Time { return start.Add(time.Duration(d)*24*time.Hour + time.Hour) }
+	invoker := types.NamespacedName{Namespace: "demo", Name: "mysql"}
+
+	oc := newOutcomes()
+	oc.addSessions([]stashv1beta1.BackupSession{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "demo", UID: "s8", CreationTimestamp: metav1.NewTime(day(8))},
+			Spec:       stashv1beta1.BackupSessionSpec{Invoker: stashv1beta1.BackupInvokerRef{Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: "mysql"}},
+			Status:     stashv1beta1.BackupSessionStatus{Phase: stashv1beta1.BackupSessionSucceeded},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "demo", UID: "s9", CreationTimestamp: metav1.NewTime(day(9))},
+			Spec:       stashv1beta1.BackupSessionSpec{Invoker: stashv1beta1.BackupInvokerRef{Kind: stashv1beta1.ResourceKindBackupConfiguration, Name: "mysql"}},
+			Status:     stashv1beta1.BackupSessionStatus{Phase: stashv1beta1.BackupSessionRunning},
+		},
+	})
+	var recorded []history.Session
+	for d, phase := range []stashv1beta1.BackupSessionPhase{
+		stashv1beta1.BackupSessionSucceeded,
+		stashv1beta1.BackupSessionFailed,
+		stashv1beta1.BackupSessionFailed,
+		stashv1beta1.BackupSessionSkipped,
+	} {
+		recorded = append(recorded, history.Session{
+			Namespace:   "demo",
+			UID:         types.UID("h" + string(rune('0'+d))),
+			InvokerKind: stashv1beta1.ResourceKindBackupConfiguration,
+			InvokerName: "mysql",
+			Phase:       phase,
+			Created:     day(d),
+		})
+	}
+	// the sessions in the cluster take precedence over the recorded history
+	recorded = append(recorded, history.Session{Namespace: "demo", UID: "s8", InvokerKind: stashv1beta1.ResourceKindBackupConfiguration, InvokerName: "mysql", Phase: stashv1beta1.BackupSessionFailed, Created: day(8)})
+	oc.addHistory(recorded)
+	oc.addEvents([]core.Event{{
+		InvolvedObject: core.ObjectReference{Kind: stashv1beta1.ResourceKindBackupSession, Namespace: "demo", Name: "mysql-" + strconv.FormatInt(day(5).Unix(), 10), UID: "e5"},
+		Reason:         "BackupSessionSucceeded",
+	}})
+
+	slo, err := computeSLO(cfg, oc.byInvoker(start, end)[invoker], start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slo.ExpectedRuns != 10 || slo.Created != 7 || slo.Succeeded != 3 || slo.Failed != 2 || slo.Skipped != 1 {
+		t.Errorf("unexpected counts %+v", slo)
+	}
+	if slo.SuccessRate == nil || *slo.SuccessRate != 30 {
+		t.Errorf("success rate = %v, expected 30", slo.SuccessRate)
+	}
+	if slo.MeanTimeBetweenFailures == nil || slo.MeanTimeBetweenFailures.Duration != 5*24*time.Hour {
+		t.Errorf("mean time between failures = %v, expected 120h", slo.MeanTimeBetweenFailures)
+	}
+	if slo.LongestGap == nil || slo.LongestGap.Duration != 5*24*time.Hour || !slo.LongestGapStart.Time.Equal(day(0)) {
+		t.Errorf("longest gap = %v from %v, expected 120h from %v", slo.LongestGap, slo.LongestGapStart, day(0))
+	}
+}
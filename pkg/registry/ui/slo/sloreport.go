@@ -0,0 +1,230 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	stashapi "stash.appscode.dev/apimachinery/apis/stash"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/apimachinery/apis/ui"
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/history"
+	"stash.appscode.dev/ui-server/pkg/shared"
+
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultReportWindow = 30 * 24 * time.Hour
+	// maxReportWindow bounds the runs expected of a BackupConfiguration
+	maxReportWindow = 366 * 24 * time.Hour
+)
+
+type BackupSLOReportStorage struct {
+	kc client.Client
+	// apiReader lists the Events, which are not cached
+	apiReader client.Reader
+	// store is the recorded history. It is nil if the history is disabled.
+	store     *history.Store
+	a         authorizer.Authorizer
+	convertor rest.TableConvertor
+}
+
+var (
+	_ rest.GroupVersionKindProvider = &BackupSLOReportStorage{}
+	_ rest.Scoper                   = &BackupSLOReportStorage{}
+	_ rest.Storage                  = &BackupSLOReportStorage{}
+	_ rest.Creater                  = &BackupSLOReportStorage{}
+	_ rest.SingularNameProvider     = &BackupSLOReportStorage{}
+)
+
+func NewBackupSLOReportStorage(kc client.Client, apiReader client.Reader, store *history.Store, a authorizer.Authorizer) *BackupSLOReportStorage {
+	return &BackupSLOReportStorage{
+		kc:        kc,
+		apiReader: apiReader,
+		store:     store,
+		a:         a,
+		convertor: rest.NewDefaultTableConvertor(schema.GroupResource{
+			Group:    ui.GroupName,
+			Resource: uisrv.ResourceBackupSLOReports,
+		}),
+	}
+}
+
+func (r *BackupSLOReportStorage) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return uisrv.SchemeGroupVersion.WithKind(uisrv.ResourceKindBackupSLOReport)
+}
+
+func (r *BackupSLOReportStorage) GetSingularName() string {
+	return strings.ToLower(uisrv.ResourceKindBackupSLOReport)
+}
+
+func (r *BackupSLOReportStorage) NamespaceScoped() bool {
+	return false
+}
+
+func (r *BackupSLOReportStorage) New() runtime.Object {
+	return &uisrv.BackupSLOReport{}
+}
+
+func (r *BackupSLOReportStorage) Destroy() {}
+
+func (r *BackupSLOReportStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	user, ok := apirequest.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing user info")
+	}
+
+	in, ok := obj.(*uisrv.BackupSLOReport)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a %s: %#v", uisrv.ResourceKindBackupSLOReport, obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, resource := range []string{stashv1beta1.ResourcePluralBackupConfiguration, stashv1beta1.ResourcePluralBackupSession} {
+		attrs := authorizer.AttributesRecord{
+			User:            user,
+			Verb:            "list",
+			Namespace:       in.Spec.Namespace,
+			APIGroup:        stashapi.GroupName,
+			Resource:        resource,
+			ResourceRequest: true,
+		}
+		decision, why, err := r.a.Authorize(ctx, attrs)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		if decision != authorizer.DecisionAllow {
+			return nil, apierrors.NewForbidden(schema.GroupResource{Group: stashapi.GroupName, Resource: resource}, "", errors.New(why))
+		}
+	}
+
+	window := defaultReportWindow
+	if in.Spec.Window != nil {
+		if in.Spec.Window.Duration <= 0 {
+			return nil, apierrors.NewBadRequest("window must be positive")
+		}
+		if in.Spec.Window.Duration > maxReportWindow {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("window must not exceed %s", maxReportWindow))
+		}
+		window = in.Spec.Window.Duration
+	}
+	end := time.Now()
+	if in.Spec.End != nil {
+		end = in.Spec.End.Time
+	}
+	start := end.Add(-window)
+
+	var cfgs stashv1beta1.BackupConfigurationList
+	if err := r.kc.List(ctx, &cfgs, client.InNamespace(in.Spec.Namespace)); err != nil {
+		return nil, err
+	}
+	sessions, err := r.outcomes(ctx, user, in.Spec.Namespace, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := in.DeepCopy()
+	result.CreationTimestamp = metav1.Now()
+	result.Status = uisrv.BackupSLOReportStatus{
+		Start: metav1.NewTime(start),
+		End:   metav1.NewTime(end),
+	}
+	for i := range cfgs.Items {
+		cfg := &cfgs.Items[i]
+		slo, err := computeSLO(cfg, sessions[types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.Name}], start, end)
+		if err != nil {
+			klog.Warningf("skipping BackupConfiguration %s/%s in the SLO report, reason: %v", cfg.Namespace, cfg.Name, err)
+			continue
+		}
+		result.Status.Configurations = append(result.Status.Configurations, slo)
+	}
+	result.Status.Namespaces = namespaceSLOs(result.Status.Configurations, window)
+	return result, nil
+}
+
+// outcomes collects the sessions created in [start, end) from the cluster,
+// the recorded history and the Events. The Events are left out with a warning
+// if u is not allowed to list them.
+func (r *BackupSLOReportStorage) outcomes(ctx context.Context, u user.Info, ns string, start, end time.Time) (map[types.NamespacedName][]outcome, error) {
+	result := newOutcomes()
+
+	var sessions stashv1beta1.BackupSessionList
+	if err := r.kc.List(ctx, &sessions, client.InNamespace(ns)); err != nil {
+		return nil, err
+	}
+	result.addSessions(sessions.Items)
+
+	if r.store != nil {
+		recorded, err := r.store.Sessions(start, end)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		if ns != "" {
+			filtered := recorded[:0]
+			for _, s := range recorded {
+				if s.Namespace == ns {
+					filtered = append(filtered, s)
+				}
+			}
+			recorded = filtered
+		}
+		result.addHistory(recorded)
+	}
+
+	if err := shared.Authorize(ctx, r.a, u, "list", ns, core.Resource("events"), ""); err != nil {
+		if !apierrors.IsForbidden(err) {
+			return nil, err
+		}
+		warning.AddWarning(ctx, "", "not allowed to list Events, sessions pruned from the cluster are not reported")
+		return result.byInvoker(start, end), nil
+	}
+	var events core.EventList
+	if err := r.apiReader.List(ctx, &events, client.InNamespace(ns), client.MatchingFields{"involvedObject.kind": stashv1beta1.ResourceKindBackupSession}); err != nil {
+		// Events only fill in sessions pruned from the cluster
+		klog.Warningf("failed to list the Events of BackupSessions, reason: %v", err)
+	} else {
+		result.addEvents(events.Items)
+	}
+
+	return result.byInvoker(start, end), nil
+}
+
+func (r *BackupSLOReportStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.convertor.ConvertToTable(ctx, object, tableOptions)
+}
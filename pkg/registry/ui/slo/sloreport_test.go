@@ -0,0 +1,100 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uisrv "stash.appscode.dev/ui-server/pkg/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/warning"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// countingReader counts the lists of Events
+type countingReader struct {
+	client.Reader
+	lists int
+}
+
+func (r *countingReader) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	r.lists++
+	return nil
+}
+
+type warningRecorder []string
+
+func (w *warningRecorder) AddWarning(_, text string) {
+	*w = append(*w, text)
+}
+
+func TestCreateReport(t *testing.T) {
+	kc := testclient.New(t)
+	ctx := apirequest.WithUser(context.TODO(), &user.DefaultInfo{Name: "alice"})
+
+	cases := []struct {
+		name     string
+		window   time.Duration
+		events   bool
+		lists    int
+		warnings int
+		invalid  bool
+	}{
+		{name: "allowed", events: true, lists: 1},
+		{name: "no list of events", lists: 0, warnings: 1},
+		{name: "window too long", window: maxReportWindow + time.Hour, events: true, invalid: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := authorizer.AuthorizerFunc(func(_ context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+				if attrs.GetResource() == "events" && !c.events {
+					return authorizer.DecisionDeny, "", nil
+				}
+				return authorizer.DecisionAllow, "", nil
+			})
+			reader := &countingReader{}
+			r := NewBackupSLOReportStorage(kc, reader, nil, a)
+
+			in := &uisrv.BackupSLOReport{}
+			if c.window > 0 {
+				in.Spec.Window = &metav1.Duration{Duration: c.window}
+			}
+			var warnings warningRecorder
+			_, err := r.Create(warning.WithWarningRecorder(ctx, &warnings), in, nil, nil)
+			if c.invalid {
+				if !apierrors.IsBadRequest(err) {
+					t.Errorf("expected a bad request, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reader.lists != c.lists || len(warnings) != c.warnings {
+				t.Errorf("listed Events %d times with warnings %v, want %d lists and %d warnings", reader.lists, warnings, c.lists, c.warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+// Authorize checks whether u may perform verb on the named resource. A denial
+// is returned as a Forbidden error, a failure of the authorizer as an internal error.
+func Authorize(ctx context.Context, a authorizer.Authorizer, u user.Info, verb, ns string, gr schema.GroupResource, name string) error {
+	attrs := authorizer.AttributesRecord{
+		User:            u,
+		Verb:            verb,
+		Namespace:       ns,
+		APIGroup:        gr.Group,
+		Resource:        gr.Resource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, why, err := a.Authorize(ctx, attrs)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return apierrors.NewForbidden(gr, name, errors.New(why))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"net/http"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// UserClient returns a client that sends requests on behalf of a user, so
+// that the writes of the storages are authorized and audited as the requester
+type UserClient func(u user.Info) (client.Client, error)
+
+// ImpersonatingClient returns a UserClient that impersonates the user. The
+// identity of cfg must be allowed to impersonate users, groups, uids and
+// user extras. The transport and the REST mapper are built once and shared
+// by the clients of all users.
+func ImpersonatingClient(cfg *restclient.Config, opts client.Options) (UserClient, error) {
+	hc, err := restclient.HTTPClientFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Mapper == nil {
+		if opts.Mapper, err = apiutil.NewDynamicRESTMapper(cfg, hc); err != nil {
+			return nil, err
+		}
+	}
+	base := hc.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return func(u user.Info) (client.Client, error) {
+		o := opts
+		o.HTTPClient = &http.Client{
+			Transport: transport.NewImpersonatingRoundTripper(transport.ImpersonationConfig{
+				UserName: u.GetName(),
+				UID:      u.GetUID(),
+				Groups:   u.GetGroups(),
+				Extra:    u.GetExtra(),
+			}, base),
+			Timeout: hc.Timeout,
+		}
+		return client.New(cfg, o)
+	}, nil
+}
+
+// DirectClient returns a UserClient that sends all requests as the identity
+// of kc. It is meant for servers whose only user is their own identity.
+func DirectClient(kc client.Client) UserClient {
+	return func(_ user.Info) (client.Client, error) {
+		return kc, nil
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+		err  bool
+	}{
+		{in: "", want: 0},
+		{in: "512", want: 512},
+		{in: "512 B", want: 512},
+		{in: "1.5 KiB", want: 1536},
+		{in: "2 MiB", want: 2 << 20},
+		{in: "1GiB", want: 1 << 30},
+		{in: "3 MB", want: 3e6},
+		{in: "1.5 XiB", err: true},
+		{in: "MiB", err: true},
+	}
+	for _, c := range cases {
+		got, err := ParseSize(c.in)
+		if c.err {
+			if err == nil {
+				t.Errorf("ParseSize(%q) expected error", c.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSize(%q) unexpected error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseSize(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	cases := map[float64]string{
+		0:                    "0 B",
+		1023:                 "1023 B",
+		1536:                 "1.500 KiB",
+		12.345 * 1024 * 1024: "12.345 MiB",
+	}
+	for in, want := range cases {
+		if got := FormatSize(in); got != want {
+			t.Errorf("FormatSize(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+)
+
+// Labels that the Stash operator sets on the resources it creates for a backup invoker
+const (
+	LabelApp         = "app"
+	AppLabelStash    = "stash"
+	LabelInvokerType = stashv1beta1.StashKey + "/invoker-type"
+	LabelInvokerName = stashv1beta1.StashKey + "/invoker-name"
+)
@@ -0,0 +1,329 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"stash.appscode.dev/apimachinery/apis/stash"
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	uiapi "stash.appscode.dev/apimachinery/apis/ui/v1alpha1"
+	"stash.appscode.dev/ui-server/pkg/apiserver"
+	"stash.appscode.dev/ui-server/pkg/registry/ui/backups"
+
+	v "gomodules.xyz/x/version"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	manifestFile  = "manifest.json"
+	checksumsFile = "checksums.txt"
+)
+
+// Options selects what is collected into a support bundle
+type Options struct {
+	// Namespace limits the namespaced objects to a namespace. All namespaces are collected if empty.
+	Namespace string
+	Redact    RedactOptions
+}
+
+// Manifest describes the content of a support bundle
+type Manifest struct {
+	CreatedAt metav1.Time   `json:"createdAt"`
+	Version   string        `json:"version"`
+	Namespace string        `json:"namespace,omitempty"`
+	Redact    RedactOptions `json:"redact"`
+	Files     []File        `json:"files"`
+	// Errors lists the objects that could not be collected
+	Errors []string `json:"errors,omitempty"`
+}
+
+// File is an entry of a support bundle
+type File struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+	Items  int    `json:"items"`
+}
+
+type bundleFile struct {
+	path  string
+	data  []byte
+	items int
+}
+
+type collector struct {
+	kc    client.Client
+	opts  Options
+	files []bundleFile
+	errs  []string
+	// uids of the collected objects, used to find the related CronJobs, Jobs and Events
+	uids sets.Set[types.UID]
+	// secretNames are the redacted Secret names, removed from the Event messages
+	secretNames sets.Set[string]
+}
+
+type stashResource struct {
+	path       string
+	list       client.ObjectList
+	namespaced bool
+}
+
+// Write collects the Stash objects along with the related Events, CronJobs and
+// Jobs and the computed overviews, and writes them as a gzipped tarball with a
+// manifest and checksums to w. Objects that can not be collected are listed as
+// errors in the manifest instead of failing the bundle.
+func Write(ctx context.Context, kc client.Client, w io.Writer, opts Options) (*Manifest, error) {
+	c := &collector{
+		kc:          kc,
+		opts:        opts,
+		uids:        sets.New[types.UID](),
+		secretNames: sets.New[string](),
+	}
+
+	var cfgs stashv1beta1.BackupConfigurationList
+	for _, r := range []stashResource{
+		{"stash/backupconfigurations.json", &cfgs, true},
+		{"stash/backupbatches.json", &stashv1beta1.BackupBatchList{}, true},
+		{"stash/backupsessions.json", &stashv1beta1.BackupSessionList{}, true},
+		{"stash/restoresessions.json", &stashv1beta1.RestoreSessionList{}, true},
+		{"stash/restorebatches.json", &stashv1beta1.RestoreBatchList{}, true},
+		{"stash/repositories.json", &stashv1alpha1.RepositoryList{}, true},
+		{"stash/backupblueprints.json", &stashv1beta1.BackupBlueprintList{}, false},
+		{"stash/tasks.json", &stashv1beta1.TaskList{}, false},
+		{"stash/functions.json", &stashv1beta1.FunctionList{}, false},
+	} {
+		items, err := c.list(ctx, r.list, r.namespaced)
+		if err != nil {
+			c.errs = append(c.errs, fmt.Sprintf("%s: %v", r.path, err))
+			continue
+		}
+		for _, item := range items {
+			if o, err := meta.Accessor(item); err == nil {
+				c.uids.Insert(o.GetUID())
+			}
+		}
+		if err := c.add(r.path, items); err != nil {
+			return nil, err
+		}
+	}
+
+	overviews := make([]runtime.Object, 0, len(cfgs.Items))
+	builder := backups.NewOverviewBuilder(kc)
+	for i := range cfgs.Items {
+		bo, err := builder.Build(ctx, cfgs.Items[i].DeepCopy())
+		if err != nil {
+			c.errs = append(c.errs, fmt.Sprintf("overview of BackupConfiguration %s/%s: %v", cfgs.Items[i].Namespace, cfgs.Items[i].Name, err))
+			continue
+		}
+		bo.SetGroupVersionKind(uiapi.SchemeGroupVersion.WithKind(uiapi.ResourceKindBackupOverview))
+		overviews = append(overviews, bo)
+	}
+	if err := c.add("overviews.json", overviews); err != nil {
+		return nil, err
+	}
+
+	if err := c.collectWorkloads(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.collectEvents(ctx); err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{
+		CreatedAt: metav1.NewTime(time.Now().UTC()),
+		Version:   v.Version.Version,
+		Namespace: opts.Namespace,
+		Redact:    opts.Redact,
+		Errors:    c.errs,
+	}
+	return m, c.write(w, m)
+}
+
+func (c *collector) listOptions(namespaced bool) []client.ListOption {
+	if namespaced && c.opts.Namespace != "" {
+		return []client.ListOption{client.InNamespace(c.opts.Namespace)}
+	}
+	return nil
+}
+
+func (c *collector) list(ctx context.Context, list client.ObjectList, namespaced bool) ([]runtime.Object, error) {
+	if err := c.kc.List(ctx, list, c.listOptions(namespaced)...); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// collectWorkloads adds the CronJobs and Jobs owned by the collected objects.
+// Only their schedule and status are kept, the pod templates are dropped.
+func (c *collector) collectWorkloads(ctx context.Context) error {
+	var cronJobs batchv1.CronJobList
+	if err := c.kc.List(ctx, &cronJobs, c.listOptions(true)...); err != nil {
+		c.errs = append(c.errs, fmt.Sprintf("cronjobs: %v", err))
+	}
+	var selected []runtime.Object
+	for i := range cronJobs.Items {
+		cj := &cronJobs.Items[i]
+		if !c.ownedByCollected(cj) {
+			continue
+		}
+		c.uids.Insert(cj.UID)
+		cj.Spec = batchv1.CronJobSpec{Schedule: cj.Spec.Schedule, Suspend: cj.Spec.Suspend}
+		selected = append(selected, cj)
+	}
+	if err := c.add("batch/cronjobs.json", selected); err != nil {
+		return err
+	}
+
+	var jobs batchv1.JobList
+	if err := c.kc.List(ctx, &jobs, c.listOptions(true)...); err != nil {
+		c.errs = append(c.errs, fmt.Sprintf("jobs: %v", err))
+	}
+	selected = nil
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !c.ownedByCollected(job) {
+			continue
+		}
+		c.uids.Insert(job.UID)
+		job.Spec = batchv1.JobSpec{BackoffLimit: job.Spec.BackoffLimit, ActiveDeadlineSeconds: job.Spec.ActiveDeadlineSeconds}
+		selected = append(selected, job)
+	}
+	return c.add("batch/jobs.json", selected)
+}
+
+func (c *collector) ownedByCollected(obj metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if c.uids.Has(ref.UID) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectEvents adds the Events of the collected objects and of all Stash
+// objects. It runs last, so that the messages are redacted with the Secret
+// names of all the other objects.
+func (c *collector) collectEvents(ctx context.Context) error {
+	var events core.EventList
+	if err := c.kc.List(ctx, &events, c.listOptions(true)...); err != nil {
+		c.errs = append(c.errs, fmt.Sprintf("events: %v", err))
+	}
+	var selected []runtime.Object
+	for i := range events.Items {
+		ev := &events.Items[i]
+		gv, _ := schema.ParseGroupVersion(ev.InvolvedObject.APIVersion)
+		if c.uids.Has(ev.InvolvedObject.UID) || strings.HasSuffix(gv.Group, stash.GroupName) {
+			selected = append(selected, ev)
+		}
+	}
+	return c.add("events.json", selected)
+}
+
+// add stores the redacted objects as a JSON List
+func (c *collector) add(path string, objs []runtime.Object) error {
+	items := make([]any, 0, len(objs))
+	for _, obj := range objs {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		if gvk, err := apiutil.GVKForObject(obj, apiserver.Scheme); err == nil {
+			u["apiVersion"], u["kind"] = gvk.GroupVersion().String(), gvk.Kind
+		}
+		redact(u, c.opts.Redact, c.secretNames)
+		items = append(items, u)
+	}
+	data, err := json.MarshalIndent(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.files = append(c.files, bundleFile{path: path, data: data, items: len(items)})
+	return nil
+}
+
+func (c *collector) write(w io.Writer, m *Manifest) error {
+	var checksums strings.Builder
+	for _, f := range c.files {
+		sum := sha256.Sum256(f.data)
+		m.Files = append(m.Files, File{
+			Path:   f.path,
+			SHA256: hex.EncodeToString(sum[:]),
+			Size:   len(f.data),
+			Items:  f.items,
+		})
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	files := append(c.files, bundleFile{path: manifestFile, data: data})
+	for _, f := range files {
+		sum := sha256.Sum256(f.data)
+		_, _ = fmt.Fprintf(&checksums, "%s  %s\n", hex.EncodeToString(sum[:]), f.path)
+	}
+	files = append(files, bundleFile{path: checksumsFile, data: []byte(checksums.String())})
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	root := "stash-support-bundle-" + m.CreatedAt.Format("20060102T150405Z") + "/"
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    root + f.path,
+			Mode:    0o644,
+			Size:    int64(len(f.data)),
+			ModTime: m.CreatedAt.Time,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,205 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	stashv1alpha1 "stash.appscode.dev/apimachinery/apis/stash/v1alpha1"
+	stashv1beta1 "stash.appscode.dev/apimachinery/apis/stash/v1beta1"
+	"stash.appscode.dev/ui-server/pkg/internal/testclient"
+
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kmapi "kmodules.xyz/client-go/api/v1"
+	store "kmodules.xyz/objectstore-api/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newBundleClient(t *testing.T) client.Client {
+	t.Helper()
+	cfg := &stashv1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "demo", UID: "cfg"},
+		Spec: stashv1beta1.BackupConfigurationSpec{
+			Repository: kmapi.ObjectReference{Name: "app-repo"},
+			Schedule:   "0 * * * *",
+		},
+	}
+	repo := &stashv1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-repo", Namespace: "demo", UID: "repo"},
+		Spec: stashv1alpha1.RepositorySpec{Backend: store.Backend{
+			StorageSecretName: "gcs-secret",
+			GCS:               &store.GCSSpec{Bucket: "backups"},
+		}},
+	}
+	podSpec := core.PodTemplateSpec{Spec: core.PodSpec{Containers: []core.Container{{Name: "backup", Image: "stash:latest"}}}}
+	owned := func(kind, name, uid string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, UID: types.UID(uid)}}
+	}
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "stash-backup-app", Namespace: "demo", UID: "cronjob", OwnerReferences: owned("BackupConfiguration", "app", "cfg")},
+		Spec: batchv1.CronJobSpec{
+			Schedule:    "0 * * * *",
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: podSpec}},
+		},
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "stash-backup-app-1", Namespace: "demo", UID: "job", OwnerReferences: owned("CronJob", "stash-backup-app", "cronjob")},
+		Spec:       batchv1.JobSpec{Template: podSpec},
+	}
+	unrelated := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "cleanup", Namespace: "demo", UID: "cleanup"},
+		Spec:       batchv1.CronJobSpec{Schedule: "@daily", JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: podSpec}}},
+	}
+	event := func(name, apiVersion, uid, msg string) *core.Event {
+		return &core.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "demo"},
+			InvolvedObject: core.ObjectReference{APIVersion: apiVersion, UID: types.UID(uid)},
+			Message:        msg,
+		}
+	}
+	return testclient.NewBuilder(t).WithObjects(
+		cfg, repo, cronJob, job, unrelated,
+		event("repo-secret", "stash.appscode.com/v1alpha1", "repo", `secret "gcs-secret" not found`),
+		event("job-failed", "batch/v1", "job", "Job has reached the specified backoff limit, gcs-secret is invalid"),
+		event("stash-deleted", "stash.appscode.com/v1beta1", "gone", "BackupSession deleted"),
+		event("pod", "v1", "pod", "Pulled image"),
+	).Build()
+}
+
+// readBundle returns the files of a support bundle by their path below the root directory
+func readBundle(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, name, _ := strings.Cut(hdr.Name, "/")
+		if !strings.HasPrefix(root, "stash-support-bundle-") {
+			t.Errorf("unexpected root of %s", hdr.Name)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = content
+	}
+	return files
+}
+
+func readList(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+	var list struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatal(err)
+	}
+	return list.Items
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	m, err := Write(context.TODO(), newBundleClient(t), &buf, Options{Redact: RedactOptions{SecretNames: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := readBundle(t, buf.Bytes())
+
+	// the manifest lists every file with its checksum, the checksums cover the manifest too
+	var manifest Manifest
+	if err := json.Unmarshal(files[manifestFile], &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Files) != len(m.Files) || len(manifest.Files) != len(files)-2 {
+		t.Errorf("manifest lists %d files, the bundle has %d", len(manifest.Files), len(files))
+	}
+	for _, f := range manifest.Files {
+		sum := sha256.Sum256(files[f.Path])
+		if hex.EncodeToString(sum[:]) != f.SHA256 || len(files[f.Path]) != f.Size {
+			t.Errorf("manifest entry of %s does not match its content", f.Path)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(files[checksumsFile])), "\n") {
+		sum, name, _ := strings.Cut(line, "  ")
+		got := sha256.Sum256(files[name])
+		if hex.EncodeToString(got[:]) != sum {
+			t.Errorf("checksum of %s does not match", name)
+		}
+	}
+	if n := strings.Count(string(files[checksumsFile]), "\n"); n != len(files)-1 {
+		t.Errorf("%d checksums for %d files", n, len(files)-1)
+	}
+
+	// only the schedule of the owned CronJob and the limits of its Job are kept
+	cronJobs := readList(t, files["batch/cronjobs.json"])
+	if len(cronJobs) != 1 || cronJobs[0]["metadata"].(map[string]any)["name"] != "stash-backup-app" {
+		t.Fatalf("unexpected CronJobs %v", cronJobs)
+	}
+	if schedule := cronJobs[0]["spec"].(map[string]any)["schedule"]; schedule != "0 * * * *" {
+		t.Errorf("CronJob schedule %v", schedule)
+	}
+	if jobs := readList(t, files["batch/jobs.json"]); len(jobs) != 1 {
+		t.Errorf("collected %d Jobs, want 1", len(jobs))
+	}
+	for _, name := range []string{"batch/cronjobs.json", "batch/jobs.json"} {
+		if strings.Contains(string(files[name]), "stash:latest") {
+			t.Errorf("the pod templates of %s must be dropped", name)
+		}
+	}
+
+	// Events of the collected and of Stash objects, without the Secret names
+	messages := map[string]string{}
+	for _, ev := range readList(t, files["events.json"]) {
+		messages[ev["metadata"].(map[string]any)["name"].(string)] = ev["message"].(string)
+	}
+	want := map[string]string{
+		"repo-secret":   `secret "REDACTED" not found`,
+		"job-failed":    "Job has reached the specified backoff limit, REDACTED is invalid",
+		"stash-deleted": "BackupSession deleted",
+	}
+	if fmt.Sprint(messages) != fmt.Sprint(want) {
+		t.Errorf("events %v, want %v", messages, want)
+	}
+	for name, content := range files {
+		if strings.Contains(string(content), "gcs-secret") {
+			t.Errorf("%s contains the Secret name", name)
+		}
+	}
+}
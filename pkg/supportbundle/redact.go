@@ -0,0 +1,141 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const redacted = "REDACTED"
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// systemAnnotationDomains are the annotation domains that are kept when user
+// defined annotations are redacted
+var systemAnnotationDomains = []string{
+	"stash.appscode.com",
+	"kubernetes.io",
+	"k8s.io",
+}
+
+// RedactOptions selects what is removed from the collected objects. The last
+// applied configuration is always removed, as it duplicates the whole object
+// including values that were redacted elsewhere.
+type RedactOptions struct {
+	// SecretNames replaces the names of referenced Secrets
+	SecretNames bool
+	// Annotations replaces the values of annotations outside of the Kubernetes and Stash domains
+	Annotations bool
+}
+
+// secretInMessage matches a quoted name following the word secret, as in
+// the messages of Events about missing Secrets
+var secretInMessage = regexp.MustCompile(`(?i)(secrets?\s+)"[^"]*"`)
+
+// redact removes sensitive values from an object in its unstructured form.
+// The redacted Secret names are added to secretNames, so that they can be
+// removed from the messages of Events collected afterwards.
+func redact(obj map[string]any, opts RedactOptions, secretNames sets.Set[string]) {
+	if md, ok := obj["metadata"].(map[string]any); ok {
+		delete(md, "managedFields")
+		if annotations, ok := md["annotations"].(map[string]any); ok {
+			delete(annotations, lastAppliedConfigAnnotation)
+			if opts.Annotations {
+				for k := range annotations {
+					if !isSystemAnnotation(k) {
+						annotations[k] = redacted
+					}
+				}
+			}
+			if len(annotations) == 0 {
+				delete(md, "annotations")
+			}
+		}
+	}
+	if opts.SecretNames {
+		redactSecretNames(obj, secretNames)
+		if obj["kind"] == "Event" {
+			if msg, ok := obj["message"].(string); ok {
+				obj["message"] = redactMessage(msg, secretNames)
+			}
+		}
+	}
+}
+
+// redactMessage replaces the quoted Secret names and the known Secret names
+// in a message
+func redactMessage(msg string, secretNames sets.Set[string]) string {
+	msg = secretInMessage.ReplaceAllString(msg, `${1}"`+redacted+`"`)
+	names := secretNames.UnsortedList()
+	// replace longer names first, so that a name does not break up another one
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	for _, name := range names {
+		if name != "" {
+			msg = strings.ReplaceAll(msg, name, redacted)
+		}
+	}
+	return msg
+}
+
+func isSystemAnnotation(key string) bool {
+	domain, _, ok := strings.Cut(key, "/")
+	if !ok {
+		return false
+	}
+	for _, d := range systemAnnotationDomains {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSecretNames replaces fields named like secretName as well as the
+// names of secretRef, secretKeyRef and secret volume references, and records
+// the replaced names
+func redactSecretNames(v any, names sets.Set[string]) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			lk := strings.ToLower(k)
+			switch {
+			case strings.HasSuffix(lk, "secretname"):
+				if name, ok := child.(string); ok {
+					names.Insert(name)
+					val[k] = redacted
+					continue
+				}
+			case lk == "secretref" || lk == "secretkeyref" || lk == "secret":
+				if ref, ok := child.(map[string]any); ok {
+					if name, ok := ref["name"].(string); ok {
+						names.Insert(name)
+						ref["name"] = redacted
+					}
+				}
+			}
+			redactSecretNames(child, names)
+		}
+	case []any:
+		for _, child := range val {
+			redactSecretNames(child, names)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Free Trial License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Free-Trial-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestRedact(t *testing.T) {
+	obj := map[string]any{}
+	if err := json.Unmarshal([]byte(`{
+  "metadata": {
+    "name": "gcs-repo",
+    "annotations": {
+      "kubectl.kubernetes.io/last-applied-configuration": "{}",
+      "stash.appscode.com/last-backup": "2024-05-15",
+      "team.example.com/owner": "alice"
+    }
+  },
+  "spec": {
+    "backend": {"gcs": {"bucket": "backups"}, "storageSecretName": "gcs-secret"},
+    "env": [{"name": "TOKEN", "valueFrom": {"secretKeyRef": {"name": "token", "key": "t"}}}]
+  }
+}`), &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	redact(obj, RedactOptions{SecretNames: true, Annotations: true}, sets.New[string]())
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"metadata":{"annotations":{"stash.appscode.com/last-backup":"2024-05-15","team.example.com/owner":"REDACTED"},"name":"gcs-repo"},` +
+		`"spec":{"backend":{"gcs":{"bucket":"backups"},"storageSecretName":"REDACTED"},"env":[{"name":"TOKEN","valueFrom":{"secretKeyRef":{"key":"t","name":"REDACTED"}}}]}}`
+	if string(data) != expected {
+		t.Errorf("redacted object = %s, expected %s", data, expected)
+	}
+}